@@ -2,12 +2,15 @@ package cmd
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
+	"os/signal"
 	"regexp"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/Kavirubc/wso2-amp-cli/internal/api"
@@ -717,7 +720,11 @@ Examples:
   amp agents logs --agent myagent --env dev --since 1h
   amp agents logs --agent myagent --env dev --level ERROR,WARN
   amp agents logs --agent myagent --env dev --search "connection failed"
-  amp agents logs --agent myagent --env dev --output json`,
+  amp agents logs --agent myagent --env dev --output json
+  amp agents logs --agent myagent --env dev --follow
+  amp agents logs --agent myagent --env dev --follow --level ERROR --output json
+  amp agents logs --agent myagent --env dev --tui --follow
+  amp agents logs --agent myagent --env dev --since 6h --level ERROR --summarize`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Get flags
 		org, _ := cmd.Flags().GetString("org")
@@ -730,6 +737,27 @@ Examples:
 		limit, _ := cmd.Flags().GetInt("limit")
 		sort, _ := cmd.Flags().GetString("sort")
 		output, _ := cmd.Flags().GetString("output")
+		follow, _ := cmd.Flags().GetBool("follow")
+		interval, _ := cmd.Flags().GetDuration("interval")
+		until, _ := cmd.Flags().GetString("until")
+		start, _ := cmd.Flags().GetString("start")
+		end, _ := cmd.Flags().GetString("end")
+		tui, _ := cmd.Flags().GetBool("tui")
+		summarize, _ := cmd.Flags().GetBool("summarize")
+		top, _ := cmd.Flags().GetInt("top")
+
+		opts, err := readLogOptions(cmd)
+		if err != nil {
+			return err
+		}
+		if tui {
+			if err := validateTUIFlags(opts, output); err != nil {
+				return err
+			}
+		}
+		if summarize && (follow || tui || opts.usesWriter()) {
+			return fmt.Errorf("--summarize cannot be combined with --follow, --tui, --format, --template or --output-file")
+		}
 
 		// Use defaults from config if not provided
 		if org == "" {
@@ -753,6 +781,14 @@ Examples:
 			return fmt.Errorf("environment name is required. Use --env flag")
 		}
 
+		// Following always shows the newest entries, oldest first
+		if follow {
+			if until != "" || end != "" {
+				return fmt.Errorf("--follow cannot be combined with --until or --end")
+			}
+			sort = "desc"
+		}
+
 		// Validate limit range
 		if limit < 1 || limit > 1000 {
 			return fmt.Errorf("limit must be between 1 and 1000")
@@ -771,14 +807,10 @@ Examples:
 			SortOrder:       sortOrder,
 		}
 
-		// Parse --since flag into start time
-		if since != "" {
-			startTime, err := util.ParseSinceDuration(since)
-			if err != nil {
-				return fmt.Errorf("invalid --since value: %w", err)
-			}
-			req.StartTime = startTime.Format(time.RFC3339)
-			req.EndTime = time.Now().Format(time.RFC3339)
+		// Resolve the time window from --since/--until or --start/--end
+		req.StartTime, req.EndTime, err = resolveLogWindow(since, until, start, end)
+		if err != nil {
+			return err
 		}
 
 		// Parse log levels
@@ -802,12 +834,50 @@ Examples:
 			config.GetAPIKeyValue(),
 		)
 
+		// Summaries read the whole window, not a single page
+		if summarize {
+			windowStart, _ := time.Parse(time.RFC3339, req.StartTime)
+			windowEnd, _ := time.Parse(time.RFC3339, req.EndTime)
+			if windowStart.IsZero() {
+				windowEnd = time.Now()
+				windowStart = windowEnd.Add(-defaultSummaryRange)
+			}
+			entries, reached, err := fetchSummaryEntries(client, org, project, agentName, req, windowStart, windowEnd)
+			if err != nil {
+				return fmt.Errorf("failed to get runtime logs: %w", err)
+			}
+			if !reached.IsZero() {
+				fmt.Fprintln(os.Stderr, ui.RenderWarning(fmt.Sprintf("Summary covers only the first %d entries, up to %s. Narrow the window with --since, --until or --level",
+					len(entries), reached.Local().Format("2006-01-02 15:04:05"))))
+				windowEnd = reached
+			}
+			summary := summarizeLogs(opts.filterEntries(entries), windowStart, windowEnd)
+			title := fmt.Sprintf("%s Log Summary: %s (%s)", ui.IconAgent, agentName, envName)
+			return printLogSummary(title, summary, top, output)
+		}
+
 		// Fetch logs from API
 		logs, err := client.GetAgentRuntimeLogs(org, project, agentName, req)
 		if err != nil {
 			return fmt.Errorf("failed to get runtime logs: %w", err)
 		}
 
+		if tui {
+			return runAgentLogsTUI(client, org, project, agentName, envName, req, logs.Logs, follow, interval, opts)
+		}
+
+		if follow {
+			return followAgentLogs(client, org, project, agentName, envName, req, logs.Logs, output, interval, opts)
+		}
+
+		// Apply local --grep/--exclude filters
+		logs.Logs = opts.filterEntries(logs.Logs)
+
+		// Plain formats and file output
+		if opts.usesWriter() {
+			return writeLogs(opts, output, false, tagLogEntries(agentName, logs.Logs))
+		}
+
 		// JSON output
 		if output == "json" {
 			encoder := json.NewEncoder(os.Stdout)
@@ -826,13 +896,7 @@ Examples:
 
 		// Print each log entry with timestamp and level
 		for _, entry := range logs.Logs {
-			timestamp := ui.FormatLogTimestamp(entry.Timestamp)
-			levelPrefix := ui.FormatLogLevel(entry.LogLevel)
-			if levelPrefix != "" {
-				fmt.Printf("[%s] %s %s\n", timestamp, levelPrefix, entry.Log)
-			} else {
-				fmt.Printf("[%s] %s\n", timestamp, entry.Log)
-			}
+			printLogEntry(entry)
 		}
 
 		fmt.Println()
@@ -842,6 +906,61 @@ Examples:
 	},
 }
 
+// followAgentLogs prints the initial entries and then streams new ones until Ctrl-C
+func followAgentLogs(client *api.Client, org, project, agentName, envName string, req api.RuntimeLogRequest, initial []api.LogEntry, output string, interval time.Duration, opts *logOptions) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Initial entries arrive newest first; show them oldest first
+	recent := make([]api.LogEntry, len(initial))
+	for i, entry := range initial {
+		recent[len(initial)-1-i] = entry
+	}
+	sortLogEntries(recent)
+
+	// Start polling after the newest entry already shown, or from the --since window
+	var start time.Time
+	if req.StartTime != "" {
+		if t, err := time.Parse(time.RFC3339, req.StartTime); err == nil {
+			start = t
+		}
+	} else if len(recent) == 0 {
+		start = time.Now()
+	}
+	follower := newLogFollower(client, org, project, agentName, req, start)
+	follower.prime(recent)
+	recent = opts.filterEntries(recent)
+
+	// Plain formats, file output and JSON are streamed entry by entry
+	if opts.usesWriter() || output == "json" {
+		w, err := newLogWriter(opts, output, false)
+		if err != nil {
+			return err
+		}
+		defer w.Close()
+		if err := w.Write(tagLogEntries(agentName, recent)); err != nil {
+			return err
+		}
+		return followLogs(ctx, []*logFollower{follower}, interval, 1, func(entries []agentLogEntry) error {
+			return w.Write(opts.filter(entries))
+		})
+	}
+
+	fmt.Println(ui.TitleStyle.Render(fmt.Sprintf("%s Runtime Logs: %s (%s)", ui.IconAgent, agentName, envName)))
+	fmt.Println(ui.MutedStyle.Render("Following new log entries. Press Ctrl+C to stop."))
+	fmt.Println()
+	for _, entry := range recent {
+		printLogEntry(entry)
+	}
+
+	return followLogs(ctx, []*logFollower{follower}, interval, 1, func(entries []agentLogEntry) error {
+		for _, entry := range opts.filter(entries) {
+			printLogEntry(entry.LogEntry)
+		}
+		return nil
+	})
+}
+
 var agentsMetricsCmd = &cobra.Command{
 	Use:   "metrics",
 	Short: "View resource metrics for a deployed agent",
@@ -851,6 +970,14 @@ Examples:
   amp agents metrics --agent myagent --env development
   amp agents metrics --agent myagent --env dev --since 1h
   amp agents metrics --agent myagent --env dev --start "2025-01-20T13:00:00Z" --end "2025-01-20T14:00:00Z"
+  amp agents metrics --agent myagent --env dev --view sparkline
+  amp agents metrics --agent myagent --env dev --view table
+  amp agents metrics --agent myagent --env dev --since 30m --watch
+  amp agents metrics --agent myagent --env dev --view stats
+  amp agents metrics --agent myagent --env prod --since 1h --assert 'cpu.p95<80%limit' --assert 'memory.max<512Mi'
+  amp agents metrics --agent myagent --env prod --since 24h --detect-anomalies
+  amp agents metrics --agent myagent --env dev --format openmetrics
+  amp agents metrics --agent myagent --env dev --since 24h --format csv > metrics.csv
   amp agents metrics --agent myagent --env dev --output json`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Get flags
@@ -862,6 +989,12 @@ Examples:
 		startTime, _ := cmd.Flags().GetString("start")
 		endTime, _ := cmd.Flags().GetString("end")
 		output, _ := cmd.Flags().GetString("output")
+		view, _ := cmd.Flags().GetString("view")
+		watch, _ := cmd.Flags().GetBool("watch")
+		interval, _ := cmd.Flags().GetDuration("interval")
+		asserts, _ := cmd.Flags().GetStringArray("assert")
+		format, _ := cmd.Flags().GetString("format")
+		detect, _ := cmd.Flags().GetBool("detect-anomalies")
 
 		// Use defaults from config if not provided
 		if org == "" {
@@ -885,22 +1018,53 @@ Examples:
 			return fmt.Errorf("environment name is required. Use --env flag")
 		}
 
+		// Parse assertions up front so typos fail before any request
+		assertions := make([]metricAssertion, 0, len(asserts))
+		for _, expr := range asserts {
+			a, err := parseMetricAssertion(expr)
+			if err != nil {
+				return err
+			}
+			assertions = append(assertions, a)
+		}
+
 		// Build metrics request
 		req := api.MetricsFilterRequest{
 			EnvironmentName: envName,
 		}
 
+		if view != metricsViewChart && view != metricsViewSparkline && view != metricsViewTable && view != metricsViewStats {
+			return fmt.Errorf("invalid --view value %q: must be chart, sparkline, table or stats", view)
+		}
+		if format != "" && format != metricsFormatOpenMetrics && format != metricsFormatCSV {
+			return fmt.Errorf("invalid --format value %q: must be openmetrics or csv", format)
+		}
+		if format != "" && (watch || len(assertions) > 0 || output == "json") {
+			return fmt.Errorf("--format cannot be combined with --watch, --assert or --output json")
+		}
+		if detect && (watch || len(assertions) > 0 || format != "") {
+			return fmt.Errorf("--detect-anomalies cannot be combined with --watch, --assert or --format")
+		}
+		anomalyOpts, err := readAnomalyOptions(cmd)
+		if err != nil {
+			return err
+		}
+		if watch && len(assertions) > 0 {
+			return fmt.Errorf("--watch cannot be combined with --assert")
+		}
+		if watch && (startTime != "" || endTime != "") {
+			return fmt.Errorf("--watch cannot be combined with --start/--end; use --since")
+		}
+		if watch && output == "json" {
+			return fmt.Errorf("--watch cannot be combined with --output json")
+		}
+
 		// Handle time range options
-		if since != "" {
-			// Parse --since flag
-			start, err := util.ParseSinceDuration(since)
-			if err != nil {
-				return fmt.Errorf("invalid --since value: %w", err)
-			}
-			req.StartTime = start.Format(time.RFC3339)
-			req.EndTime = time.Now().Format(time.RFC3339)
-		} else if startTime != "" || endTime != "" {
+		if startTime != "" || endTime != "" {
 			// Use explicit start/end times
+			if since != "" {
+				return fmt.Errorf("--since cannot be combined with --start/--end")
+			}
 			if startTime == "" || endTime == "" {
 				return fmt.Errorf("both --start and --end must be provided together")
 			}
@@ -913,10 +1077,11 @@ Examples:
 			}
 			req.StartTime = startTime
 			req.EndTime = endTime
-		} else {
-			// Default to last 1 hour
-			req.StartTime = time.Now().Add(-1 * time.Hour).Format(time.RFC3339)
-			req.EndTime = time.Now().Format(time.RFC3339)
+		} else if since != "" {
+			// Validate --since once; relative windows are recomputed on every fetch
+			if _, err := util.ParseSinceDuration(since); err != nil {
+				return fmt.Errorf("invalid --since value: %w", err)
+			}
 		}
 
 		// Create API client
@@ -926,55 +1091,103 @@ Examples:
 			config.GetAPIKeyValue(),
 		)
 
-		// Fetch metrics from API
-		metrics, err := client.GetAgentMetrics(org, project, agentName, req)
-		if err != nil {
-			return fmt.Errorf("failed to get metrics: %w", err)
+		// fetch updates a relative window to end now and loads the metrics
+		fetch := func() (*api.MetricsResponse, error) {
+			if startTime == "" {
+				start := time.Now().Add(-1 * time.Hour)
+				if since != "" {
+					start, _ = util.ParseSinceDuration(since)
+				}
+				req.StartTime = start.Format(time.RFC3339)
+				req.EndTime = time.Now().Format(time.RFC3339)
+			}
+			metrics, err := client.GetAgentMetrics(org, project, agentName, req)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get metrics: %w", err)
+			}
+			return metrics, nil
 		}
 
-		// JSON output
-		if output == "json" {
-			encoder := json.NewEncoder(os.Stdout)
-			encoder.SetIndent("", "  ")
-			return encoder.Encode(metrics)
+		if watch {
+			return watchAgentMetrics(fetch, &req, agentName, envName, view, interval)
 		}
 
-		// Check if there's any data
-		if !ui.HasMetricsData(metrics) {
-			fmt.Println(ui.RenderWarning("No metrics data found for the specified criteria."))
-			return nil
+		// Fetch metrics from API
+		metrics, err := fetch()
+		if err != nil {
+			return err
 		}
 
-		// Display metrics
-		fmt.Println(ui.TitleStyle.Render(fmt.Sprintf("%s Resource Metrics for %s (%s)", ui.IconMetrics, agentName, envName)))
-		fmt.Println()
-
-		// Show time range
-		startDisplay := ui.FormatMetricTimestamp(req.StartTime)
-		endDisplay := ui.FormatMetricTimestamp(req.EndTime)
-		fmt.Printf("  %s  %s - %s\n", ui.KeyStyle.Render("Time Range:"), startDisplay, endDisplay)
-		fmt.Println()
+		if detect {
+			report := anomalyReport{
+				Project:     project,
+				Environment: envName,
+				StartTime:   req.StartTime,
+				EndTime:     req.EndTime,
+				Agents:      []string{agentName},
+				Anomalies:   detectAnomalies(agentName, envName, metrics, anomalyOpts),
+			}
+			sortAnomalies(report.Anomalies)
+			title := fmt.Sprintf("%s Metric Anomalies for %s (%s)", ui.IconMetrics, agentName, envName)
+			return writeAnomalyReport(title, report, output)
+		}
+
+		// Export formats
+		if format != "" {
+			samples := []agentMetricsSample{{
+				Labels:  metricLabels{Agent: agentName, Project: project, Environment: envName},
+				Metrics: metrics,
+			}}
+			if format == metricsFormatCSV {
+				return writeMetricsCSV(os.Stdout, samples)
+			}
+			return writeOpenMetrics(os.Stdout, samples, false)
+		}
 
-		// CPU Usage table
-		if len(metrics.CpuUsage) > 0 || len(metrics.CpuRequests) > 0 || len(metrics.CpuLimits) > 0 {
-			fmt.Println(ui.SectionStyle.Render("  CPU Usage:"))
-			headers, rows := ui.BuildCPUMetricsTable(metrics.CpuUsage, metrics.CpuRequests, metrics.CpuLimits)
-			if len(rows) > 0 {
-				fmt.Println(ui.RenderTable(headers, rows))
+		// Evaluate assertions against the fetched window
+		results := make([]metricAssertionResult, len(assertions))
+		failed := 0
+		for i, a := range assertions {
+			results[i] = evaluateMetricAssertion(a, metrics)
+			if !results[i].Passed {
+				failed++
 			}
-			fmt.Println()
 		}
 
-		// Memory Usage table
-		if len(metrics.Memory) > 0 || len(metrics.MemoryRequests) > 0 || len(metrics.MemoryLimits) > 0 {
-			fmt.Println(ui.SectionStyle.Render("  Memory Usage:"))
-			headers, rows := ui.BuildMemoryMetricsTable(metrics.Memory, metrics.MemoryRequests, metrics.MemoryLimits)
-			if len(rows) > 0 {
-				fmt.Println(ui.RenderTable(headers, rows))
+		// JSON output
+		if output == "json" {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			if view == metricsViewStats || len(assertions) > 0 {
+				err = encoder.Encode(metricsReport{
+					Agent:       agentName,
+					Environment: envName,
+					StartTime:   req.StartTime,
+					EndTime:     req.EndTime,
+					Stats:       collectMetricStats(metrics),
+					Assertions:  results,
+				})
+			} else {
+				err = encoder.Encode(metrics)
+			}
+		} else if !ui.HasMetricsData(metrics) {
+			// Check if there's any data
+			fmt.Println(ui.RenderWarning("No metrics data found for the specified criteria."))
+		} else {
+			printAgentMetrics(metrics, req, agentName, envName, view)
+			if len(results) > 0 {
+				printAssertionResults(results)
 			}
-			fmt.Println()
+		}
+		if err != nil {
+			return err
 		}
 
+		if failed > 0 {
+			// A failed check is not a usage mistake
+			cmd.SilenceUsage = true
+			return fmt.Errorf("%d of %d metric assertions failed", failed, len(assertions))
+		}
 		return nil
 	},
 }
@@ -996,6 +1209,7 @@ Examples:
 		envName, _ := cmd.Flags().GetString("env")
 		output, _ := cmd.Flags().GetString("output")
 		showSecrets, _ := cmd.Flags().GetBool("show-secrets")
+		force, _ := cmd.Flags().GetBool("force")
 
 		// Use defaults from config if not provided
 		if org == "" {
@@ -1019,6 +1233,12 @@ Examples:
 			return fmt.Errorf("environment name is required. Use --env flag")
 		}
 
+		// Load secret-detection policy
+		policy, err := loadSecretPolicy()
+		if err != nil {
+			return err
+		}
+
 		// Create API client
 		client := api.NewClient(
 			config.GetAPIURL(),
@@ -1026,30 +1246,36 @@ Examples:
 			config.GetAPIKeyValue(),
 		)
 
+		// Revealing production secrets requires explicit confirmation
+		if showSecrets {
+			ok, err := confirmShowSecrets(client, org, []string{envName}, force)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return nil
+			}
+		}
+
 		// Fetch configuration from API
 		configResp, err := client.GetAgentConfigurations(org, project, agentName, envName)
 		if err != nil {
 			return fmt.Errorf("failed to get configuration: %w", err)
 		}
 
-		// JSON output - apply masking unless --show-secrets is set
+		// Mask sensitive values unless --show-secrets is specified
+		configs := configResp.Configurations
+		if !showSecrets {
+			configs = maskEnvironmentVariables(policy, configResp.Configurations)
+		}
+
+		// JSON output
 		if output == "json" {
-			outputResp := configResp
-			if !showSecrets {
-				// Create a copy with masked values for sensitive keys
-				maskedConfigs := make([]api.EnvironmentVariable, len(configResp.Configurations))
-				for i, cfg := range configResp.Configurations {
-					maskedConfigs[i] = api.EnvironmentVariable{Key: cfg.Key, Value: cfg.Value}
-					if isSensitiveKey(cfg.Key) {
-						maskedConfigs[i].Value = maskSensitiveValue(cfg.Value)
-					}
-				}
-				outputResp = &api.ConfigurationResponse{
-					ProjectName:    configResp.ProjectName,
-					AgentName:      configResp.AgentName,
-					Environment:    configResp.Environment,
-					Configurations: maskedConfigs,
-				}
+			outputResp := &api.ConfigurationResponse{
+				ProjectName:    configResp.ProjectName,
+				AgentName:      configResp.AgentName,
+				Environment:    configResp.Environment,
+				Configurations: configs,
 			}
 			encoder := json.NewEncoder(os.Stdout)
 			encoder.SetIndent("", "  ")
@@ -1057,7 +1283,7 @@ Examples:
 		}
 
 		// Check if there are any configurations
-		if len(configResp.Configurations) == 0 {
+		if len(configs) == 0 {
 			fmt.Println(ui.RenderWarning("No environment variables configured for this agent."))
 			return nil
 		}
@@ -1069,14 +1295,9 @@ Examples:
 
 		// Build table data
 		headers := []string{"KEY", "VALUE"}
-		rows := make([][]string, len(configResp.Configurations))
-		for i, cfg := range configResp.Configurations {
-			value := cfg.Value
-			// Mask sensitive values unless --show-secrets is specified
-			if !showSecrets && isSensitiveKey(cfg.Key) {
-				value = maskSensitiveValue(cfg.Value)
-			}
-			rows[i] = []string{cfg.Key, value}
+		rows := make([][]string, len(configs))
+		for i, cfg := range configs {
+			rows[i] = []string{cfg.Key, cfg.Value}
 		}
 
 		// Render table
@@ -1084,7 +1305,7 @@ Examples:
 		fmt.Println()
 
 		// Show hint about --show-secrets if any values are masked
-		if !showSecrets && hasAnySensitiveKey(configResp.Configurations) {
+		if !showSecrets && hasAnySensitiveKey(policy, configResp.Configurations) {
 			fmt.Println(ui.MutedStyle.Render("  Tip: Use --show-secrets to reveal masked values"))
 			fmt.Println()
 		}
@@ -1093,51 +1314,6 @@ Examples:
 	},
 }
 
-// isSensitiveKey checks if a key name suggests it contains sensitive data
-func isSensitiveKey(key string) bool {
-	upperKey := strings.ToUpper(strings.TrimSpace(key))
-
-	// Check for keys ending with _KEY (e.g., API_KEY, SECRET_KEY, PRIVATE_KEY)
-	if strings.HasSuffix(upperKey, "_KEY") {
-		return true
-	}
-
-	// Check for substring patterns that indicate sensitive data
-	lowerKey := strings.ToLower(key)
-	sensitivePatterns := []string{
-		"secret", "password", "passwd", "pwd", "token", "api_key", "apikey",
-		"credential", "private_key", "access_key", "cert", "certificate",
-		"auth_token", "bearer", "jwt",
-	}
-	for _, pattern := range sensitivePatterns {
-		if strings.Contains(lowerKey, pattern) {
-			return true
-		}
-	}
-	return false
-}
-
-// maskSensitiveValue returns a masked version of the value, showing first 2 and last 2 chars
-func maskSensitiveValue(value string) string {
-	if len(value) == 0 {
-		return "(empty)"
-	}
-	if len(value) <= 4 {
-		return "****"
-	}
-	return value[:2] + strings.Repeat("*", len(value)-4) + value[len(value)-2:]
-}
-
-// hasAnySensitiveKey checks if any configuration has a sensitive key
-func hasAnySensitiveKey(configs []api.EnvironmentVariable) bool {
-	for _, cfg := range configs {
-		if isSensitiveKey(cfg.Key) {
-			return true
-		}
-	}
-	return false
-}
-
 func init() {
 	rootCmd.AddCommand(agentsCmd)
 	agentsCmd.AddCommand(agentsListCmd)
@@ -1176,6 +1352,13 @@ func init() {
 	agentsLogsCmd.Flags().String("search", "", "Search phrase to filter logs")
 	agentsLogsCmd.Flags().Int("limit", 100, "Maximum number of log entries to return")
 	agentsLogsCmd.Flags().String("sort", "desc", "Sort order (asc/desc)")
+	agentsLogsCmd.Flags().BoolP("follow", "f", false, "Stream new log entries until interrupted")
+	agentsLogsCmd.Flags().Duration("interval", defaultFollowInterval, "Polling interval when following logs")
+	agentsLogsCmd.Flags().Bool("tui", false, "Open logs in a full-screen interactive viewer")
+	agentsLogsCmd.Flags().Bool("summarize", false, "Group similar messages into templates and show a level histogram")
+	agentsLogsCmd.Flags().Int("top", 10, "Number of templates to show with --summarize")
+	addLogWindowFlags(agentsLogsCmd)
+	addLogOutputFlags(agentsLogsCmd)
 
 	// Add flags for metrics command
 	agentsMetricsCmd.Flags().StringP("agent", "a", "", "Agent name (required)")
@@ -1183,9 +1366,17 @@ func init() {
 	agentsMetricsCmd.Flags().String("since", "", "Show metrics since duration (e.g., 1h, 24h, 7d)")
 	agentsMetricsCmd.Flags().String("start", "", "Start time (RFC3339 format)")
 	agentsMetricsCmd.Flags().String("end", "", "End time (RFC3339 format)")
+	agentsMetricsCmd.Flags().String("view", metricsViewChart, "Display as chart, sparkline, table or stats")
+	agentsMetricsCmd.Flags().StringArray("assert", nil, "Fail if an expression does not hold, e.g. 'cpu.p95<80%limit' or 'memory.max<512Mi' (repeatable)")
+	agentsMetricsCmd.Flags().Bool("detect-anomalies", false, "Report spikes, monotonic growth and limit saturation instead of charts")
+	addAnomalyFlags(agentsMetricsCmd)
+	agentsMetricsCmd.Flags().String("format", "", "Export every series as openmetrics or csv")
+	agentsMetricsCmd.Flags().BoolP("watch", "w", false, "Refresh the display until interrupted")
+	agentsMetricsCmd.Flags().Duration("interval", defaultMetricsWatchInterval, "Refresh interval for --watch")
 
 	// Add flags for config command
 	agentsConfigCmd.Flags().StringP("agent", "a", "", "Agent name (required)")
 	agentsConfigCmd.Flags().StringP("env", "e", "", "Environment name (required)")
 	agentsConfigCmd.Flags().Bool("show-secrets", false, "Show unmasked values for sensitive variables")
+	agentsConfigCmd.Flags().BoolP("force", "f", false, "Skip the confirmation prompt when revealing production secrets")
 }
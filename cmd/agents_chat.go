@@ -0,0 +1,415 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Kavirubc/wso2-amp-cli/internal/api"
+	"github.com/Kavirubc/wso2-amp-cli/internal/config"
+	"github.com/Kavirubc/wso2-amp-cli/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+)
+
+// chatReplyKeys are the fields a chat reply or stream chunk may carry its text in
+var chatReplyKeys = []string{"delta", "content", "response", "reply", "message", "output", "text", "token"}
+
+// chatTranscript is the file format of /save and /load
+type chatTranscript struct {
+	Agent       string           `json:"agent"`
+	Environment string           `json:"environment"`
+	SessionID   string           `json:"sessionId"`
+	SavedAt     time.Time        `json:"savedAt"`
+	Messages    []ui.ChatMessage `json:"messages"`
+}
+
+// chatSession sends turns of one conversation to a chat-api agent
+type chatSession struct {
+	client    *api.Client
+	org       string
+	project   string
+	agentName string
+	envName   string
+	url       string
+	token     string
+	stream    bool
+	sessionID string
+}
+
+// newChatSessionID returns a random id that groups the turns of a conversation
+func newChatSessionID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
+
+// Lookup of the trace a turn started with its traceparent header
+const (
+	chatTraceLookups       = 3
+	chatTraceLookupBackoff = 500 * time.Millisecond
+)
+
+// newTraceParent returns a W3C traceparent header value and its trace id
+func newTraceParent() (header, traceID string) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", ""
+	}
+	traceID = hex.EncodeToString(b[:16])
+	return "00-" + traceID + "-" + hex.EncodeToString(b[16:]) + "-01", traceID
+}
+
+// send posts one user turn and streams the reply to onChunk
+func (s *chatSession) send(ctx context.Context, history []ui.ChatMessage, onChunk func(string)) (ui.ChatMessage, error) {
+	reply := ui.ChatMessage{Role: ui.ChatRoleAssistant}
+	if len(history) == 0 {
+		return reply, fmt.Errorf("nothing to send")
+	}
+
+	payload := map[string]interface{}{
+		"message":    history[len(history)-1].Content,
+		"session_id": s.sessionID,
+	}
+	if s.stream {
+		payload["stream"] = true
+	}
+	body, _ := json.Marshal(payload)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return reply, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.stream {
+		req.Header.Set("Accept", "text/event-stream, application/json")
+	} else {
+		req.Header.Set("Accept", "application/json")
+	}
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+	// The agent joins this trace when it propagates W3C trace context
+	traceParent, sentTraceID := newTraceParent()
+	if traceParent != "" {
+		req.Header.Set("traceparent", traceParent)
+	}
+
+	started := time.Now()
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return reply, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var content strings.Builder
+	emit := func(chunk string) {
+		content.WriteString(chunk)
+		onChunk(chunk)
+	}
+
+	if resp.StatusCode >= 400 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		err = fmt.Errorf("agent returned %s: %s", resp.Status, strings.TrimSpace(string(b)))
+	} else if strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		err = readChatEventStream(resp.Body, emit)
+	} else if strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") {
+		var b []byte
+		if b, err = io.ReadAll(resp.Body); err == nil {
+			emit(chatReplyText(b))
+		}
+	} else {
+		// Chunked plain text is shown as it arrives
+		buf := make([]byte, 1024)
+		for {
+			n, readErr := resp.Body.Read(buf)
+			if n > 0 {
+				emit(string(buf[:n]))
+			}
+			if readErr == io.EOF {
+				break
+			}
+			if readErr != nil {
+				err = readErr
+				break
+			}
+		}
+	}
+
+	reply.Content = strings.TrimSpace(content.String())
+	reply.Time = time.Now()
+	reply.LatencyMs = time.Since(started).Milliseconds()
+	reply.TraceID = traceIDFromHeaders(resp.Header)
+	if reply.TraceID == "" && sentTraceID != "" && err == nil {
+		reply.TraceID = s.findTrace(ctx, sentTraceID)
+	}
+	if ctx.Err() != nil {
+		return reply, fmt.Errorf("reply stopped")
+	}
+	return reply, err
+}
+
+// readChatEventStream emits the text of each server-sent event until [DONE]
+func readChatEventStream(r io.Reader, emit func(string)) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+		data := strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " ")
+		if data == "[DONE]" {
+			return nil
+		}
+		if json.Valid([]byte(data)) {
+			emit(chatReplyText([]byte(data)))
+		} else {
+			emit(data)
+		}
+	}
+	return scanner.Err()
+}
+
+// chatReplyText extracts the reply text from a JSON response or stream chunk
+func chatReplyText(body []byte) string {
+	var obj map[string]interface{}
+	if err := json.Unmarshal(body, &obj); err != nil {
+		var s string
+		if json.Unmarshal(body, &s) == nil {
+			return s
+		}
+		return string(body)
+	}
+	for _, key := range chatReplyKeys {
+		switch v := obj[key].(type) {
+		case string:
+			return v
+		case map[string]interface{}:
+			// e.g. {"message": {"role": "assistant", "content": "..."}}
+			if s, ok := v["content"].(string); ok {
+				return s
+			}
+		}
+	}
+	// OpenAI-style choices
+	if choices, ok := obj["choices"].([]interface{}); ok && len(choices) > 0 {
+		if choice, ok := choices[0].(map[string]interface{}); ok {
+			for _, key := range []string{"delta", "message"} {
+				if m, ok := choice[key].(map[string]interface{}); ok {
+					if s, ok := m["content"].(string); ok {
+						return s
+					}
+				}
+			}
+			if s, ok := choice["text"].(string); ok {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+// traceIDFromHeaders reads the trace id the agent reported for a request
+func traceIDFromHeaders(h http.Header) string {
+	if id := h.Get("X-Trace-Id"); id != "" {
+		return id
+	}
+	// W3C traceparent: version-traceid-parentid-flags
+	if parts := strings.Split(h.Get("Traceparent"), "-"); len(parts) == 4 {
+		return parts[1]
+	}
+	return h.Get("X-B3-TraceId")
+}
+
+// findTrace returns the trace id once the platform has recorded that trace.
+// Traces arrive with a delay, so it asks a few times; an agent that does not
+// propagate the traceparent header never gets one.
+func (s *chatSession) findTrace(ctx context.Context, traceID string) string {
+	for i := 0; i < chatTraceLookups; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return ""
+			case <-time.After(chatTraceLookupBackoff):
+			}
+		}
+		if trace, err := s.client.GetTrace(s.org, s.project, s.agentName, traceID, s.envName); err == nil && len(trace.Spans) > 0 {
+			return traceID
+		}
+	}
+	return ""
+}
+
+// saveTranscript writes the conversation to a JSON file
+func (s *chatSession) saveTranscript(path string, messages []ui.ChatMessage) error {
+	transcript := chatTranscript{
+		Agent:       s.agentName,
+		Environment: s.envName,
+		SessionID:   s.sessionID,
+		SavedAt:     time.Now(),
+		Messages:    messages,
+	}
+	data, err := json.MarshalIndent(transcript, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// loadTranscript reads a saved conversation and continues its session
+func (s *chatSession) loadTranscript(path string) ([]ui.ChatMessage, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var transcript chatTranscript
+	if err := json.Unmarshal(data, &transcript); err != nil {
+		return nil, fmt.Errorf("invalid transcript: %w", err)
+	}
+	if transcript.SessionID != "" {
+		s.sessionID = transcript.SessionID
+	}
+	return transcript.Messages, nil
+}
+
+var agentsChatCmd = &cobra.Command{
+	Use:   "chat",
+	Short: "Chat with a deployed chat-api agent",
+	Long: `Open an interactive conversation with a deployed chat-api agent.
+
+Each message is sent to the agent's endpoint with a session id, and streamed
+replies (server-sent events or chunked text) are shown as they arrive. After
+each reply its trace id is shown, ready for 'amp traces get', when the agent
+reports it or joins the trace context the chat sends.
+
+Commands inside the chat:
+  /reset          Start a new conversation with a new session id
+  /save <file>    Save the transcript as JSON
+  /load <file>    Load a transcript and continue its session
+  /help           Show commands
+  /quit           Exit (or ctrl+c)
+
+Press esc to stop a reply and pgup/pgdown to scroll.
+
+Examples:
+  amp agents chat --agent myagent --env dev
+  amp agents chat --agent myagent --env dev --transcript session.json
+  amp agents chat --agent myagent --env dev --path /invocations --no-stream`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flags
+		org, _ := cmd.Flags().GetString("org")
+		project, _ := cmd.Flags().GetString("project")
+		agentName, _ := cmd.Flags().GetString("agent")
+		envName, _ := cmd.Flags().GetString("env")
+		endpointName, _ := cmd.Flags().GetString("endpoint")
+		path, _ := cmd.Flags().GetString("path")
+		noStream, _ := cmd.Flags().GetBool("no-stream")
+		noAuth, _ := cmd.Flags().GetBool("no-auth")
+		transcriptFile, _ := cmd.Flags().GetString("transcript")
+
+		// Use defaults from config if not provided
+		if org == "" {
+			org = config.GetDefaultOrg()
+		}
+		if project == "" {
+			project = config.GetDefaultProject()
+		}
+
+		// Validate required fields
+		if org == "" {
+			return fmt.Errorf("organization is required. Use --org flag or set default with: amp config set default_org <name>")
+		}
+		if project == "" {
+			return fmt.Errorf("project is required. Use --project flag or set default with: amp config set default_project <name>")
+		}
+		if agentName == "" {
+			return fmt.Errorf("agent name is required. Use --agent flag")
+		}
+		if envName == "" {
+			return fmt.Errorf("environment name is required. Use --env flag")
+		}
+
+		// Create API client
+		client := api.NewClient(
+			config.GetAPIURL(),
+			config.GetAPIKeyHeader(),
+			config.GetAPIKeyValue(),
+		)
+
+		agent, err := client.GetAgent(org, project, agentName)
+		if err != nil {
+			return fmt.Errorf("failed to get agent: %w", err)
+		}
+		if agent.AgentType != nil && agent.AgentType.SubType != "" && agent.AgentType.SubType != "chat-api" {
+			return fmt.Errorf("agent %q is a %s agent; chat needs a chat-api agent (use 'amp agents invoke' instead)", agentName, agent.AgentType.SubType)
+		}
+
+		endpoint, err := resolveAgentEndpoint(client, org, project, agentName, envName, endpointName)
+		if err != nil {
+			return err
+		}
+
+		session := &chatSession{
+			client:    client,
+			org:       org,
+			project:   project,
+			agentName: agentName,
+			envName:   envName,
+			url:       joinEndpointPath(endpoint.URL, path),
+			stream:    !noStream,
+			sessionID: newChatSessionID(),
+		}
+		if !noAuth {
+			if session.token, err = agentBearerToken(client, org, project, agentName, "", false); err != nil {
+				return err
+			}
+		}
+
+		var history []ui.ChatMessage
+		if transcriptFile != "" {
+			if history, err = session.loadTranscript(transcriptFile); err != nil {
+				return fmt.Errorf("failed to load transcript: %w", err)
+			}
+		}
+
+		model := ui.NewChatModel(ui.ChatOptions{
+			Title: fmt.Sprintf("%s Chat · %s · %s", ui.IconAgent, agentName, envName),
+			Send:  session.send,
+			Reset: func() { session.sessionID = newChatSessionID() },
+			Save:  session.saveTranscript,
+			Load:  session.loadTranscript,
+			TraceHint: func(traceID string) string {
+				return fmt.Sprintf("trace %s · amp traces get %s --agent %s --env %s", traceID, traceID, agentName, envName)
+			},
+		}, history)
+
+		if _, err := tea.NewProgram(model, tea.WithAltScreen()).Run(); err != nil {
+			return fmt.Errorf("chat failed: %w", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	agentsCmd.AddCommand(agentsChatCmd)
+
+	agentsChatCmd.Flags().StringP("agent", "a", "", "Agent name (required)")
+	agentsChatCmd.Flags().StringP("env", "e", "", "Environment name (required)")
+	agentsChatCmd.Flags().String("endpoint", "", "Endpoint name (when the agent has several)")
+	agentsChatCmd.Flags().String("path", "/chat", "Path appended to the endpoint URL")
+	agentsChatCmd.Flags().Bool("no-stream", false, "Ask for a complete reply instead of a stream")
+	agentsChatCmd.Flags().Bool("no-auth", false, "Send requests without an agent token")
+	agentsChatCmd.Flags().String("transcript", "", "Continue a conversation saved with /save")
+}
@@ -0,0 +1,422 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/Kavirubc/wso2-amp-cli/internal/api"
+	"github.com/Kavirubc/wso2-amp-cli/internal/config"
+	"github.com/Kavirubc/wso2-amp-cli/internal/secrets"
+	"github.com/Kavirubc/wso2-amp-cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// Diff statuses for a configuration key
+const (
+	configDiffMissing   = "missing"
+	configDiffExtra     = "extra"
+	configDiffChanged   = "changed"
+	configDiffUnchanged = "unchanged"
+)
+
+// configDiffEntry describes how a single key differs between two environments
+type configDiffEntry struct {
+	Key    string `json:"key"`
+	Status string `json:"status"`
+	From   string `json:"from,omitempty"`
+	To     string `json:"to,omitempty"`
+}
+
+// configDiffResult is the JSON shape of a two-environment diff
+type configDiffResult struct {
+	AgentName string            `json:"agentName"`
+	From      string            `json:"from"`
+	To        string            `json:"to"`
+	Entries   []configDiffEntry `json:"entries"`
+}
+
+// configMatrixResult is the JSON shape of a multi-environment matrix
+type configMatrixResult struct {
+	AgentName    string                       `json:"agentName"`
+	Environments []string                     `json:"environments"`
+	Values       map[string]map[string]string `json:"values"`
+	Differs      []string                     `json:"differs"`
+}
+
+var agentsConfigDiffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compare environment variables of an agent across environments",
+	Long: `Compare the environment variables configured for an agent in two environments,
+or across every environment in the project's deployment pipeline.
+
+Keys are reported as missing (only in --from), extra (only in --to) or changed.
+Sensitive values are masked unless --show-secrets is set; --hash-only replaces
+every value with a short SHA-256 digest so configs can be compared without
+revealing them.
+
+Examples:
+  amp agents config diff --agent myagent --from development --to production
+  amp agents config diff --agent myagent --from dev --to prod --hash-only
+  amp agents config diff --agent myagent --matrix
+  amp agents config diff --agent myagent --from dev --to prod --output json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flags
+		org, _ := cmd.Flags().GetString("org")
+		project, _ := cmd.Flags().GetString("project")
+		agentName, _ := cmd.Flags().GetString("agent")
+		fromEnv, _ := cmd.Flags().GetString("from")
+		toEnv, _ := cmd.Flags().GetString("to")
+		matrix, _ := cmd.Flags().GetBool("matrix")
+		hashOnly, _ := cmd.Flags().GetBool("hash-only")
+		showSecrets, _ := cmd.Flags().GetBool("show-secrets")
+		showAll, _ := cmd.Flags().GetBool("all")
+		force, _ := cmd.Flags().GetBool("force")
+		output, _ := cmd.Flags().GetString("output")
+
+		// Use defaults from config if not provided
+		if org == "" {
+			org = config.GetDefaultOrg()
+		}
+		if project == "" {
+			project = config.GetDefaultProject()
+		}
+
+		// Validate required fields
+		if org == "" {
+			return fmt.Errorf("organization is required. Use --org flag or set default with: amp config set default_org <name>")
+		}
+		if project == "" {
+			return fmt.Errorf("project is required. Use --project flag or set default with: amp config set default_project <name>")
+		}
+		if agentName == "" {
+			return fmt.Errorf("agent name is required. Use --agent flag")
+		}
+		if !matrix && (fromEnv == "" || toEnv == "") {
+			return fmt.Errorf("both --from and --to environments are required (or use --matrix)")
+		}
+		if hashOnly && showSecrets {
+			return fmt.Errorf("--hash-only and --show-secrets cannot be used together")
+		}
+
+		// Load secret-detection policy
+		policy, err := loadSecretPolicy()
+		if err != nil {
+			return err
+		}
+
+		// Create API client
+		client := api.NewClient(
+			config.GetAPIURL(),
+			config.GetAPIKeyHeader(),
+			config.GetAPIKeyValue(),
+		)
+
+		// displayValue renders a value according to the masking options
+		displayValue := func(key, value string) string {
+			if hashOnly {
+				return hashConfigValue(value)
+			}
+			if !showSecrets && policy.IsSensitive(key, value) {
+				return secrets.Mask(value)
+			}
+			return value
+		}
+
+		if matrix {
+			return runConfigMatrix(client, org, project, agentName, output, showAll, showSecrets, force, displayValue)
+		}
+
+		// Revealing production secrets requires explicit confirmation
+		if showSecrets {
+			ok, err := confirmShowSecrets(client, org, []string{fromEnv, toEnv}, force)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return nil
+			}
+		}
+
+		// Fetch configuration for both environments
+		fromResp, err := client.GetAgentConfigurations(org, project, agentName, fromEnv)
+		if err != nil {
+			return fmt.Errorf("failed to get configuration for %s: %w", fromEnv, err)
+		}
+		toResp, err := client.GetAgentConfigurations(org, project, agentName, toEnv)
+		if err != nil {
+			return fmt.Errorf("failed to get configuration for %s: %w", toEnv, err)
+		}
+
+		entries := diffConfigurations(fromResp.Configurations, toResp.Configurations)
+
+		// Apply masking and drop unchanged keys unless --all is set
+		var shown []configDiffEntry
+		for _, e := range entries {
+			if e.Status == configDiffUnchanged && !showAll {
+				continue
+			}
+			if e.Status != configDiffExtra {
+				e.From = displayValue(e.Key, e.From)
+			}
+			if e.Status != configDiffMissing {
+				e.To = displayValue(e.Key, e.To)
+			}
+			shown = append(shown, e)
+		}
+
+		// JSON output
+		if output == "json" {
+			result := configDiffResult{
+				AgentName: agentName,
+				From:      fromEnv,
+				To:        toEnv,
+				Entries:   shown,
+			}
+			if result.Entries == nil {
+				result.Entries = []configDiffEntry{}
+			}
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(result)
+		}
+
+		title := fmt.Sprintf("%s Config Diff for %s (%s → %s)", ui.IconAgent, agentName, fromEnv, toEnv)
+		fmt.Println(ui.TitleStyle.Render(title))
+		fmt.Println()
+
+		if len(shown) == 0 {
+			fmt.Println(ui.RenderSuccess("No differences found."))
+			return nil
+		}
+
+		// Build table data
+		headers := []string{"KEY", "STATUS", fromEnv, toEnv}
+		rows := make([][]string, len(shown))
+		for i, e := range shown {
+			rows[i] = []string{e.Key, configDiffStatusCell(e.Status), valueOrDefault(e.From, "-"), valueOrDefault(e.To, "-")}
+		}
+		fmt.Println(ui.RenderTable(headers, rows))
+		fmt.Println()
+
+		// Summary line
+		counts := make(map[string]int)
+		for _, e := range entries {
+			counts[e.Status]++
+		}
+		fmt.Println(ui.MutedStyle.Render(fmt.Sprintf("  %d missing, %d extra, %d changed, %d unchanged",
+			counts[configDiffMissing], counts[configDiffExtra], counts[configDiffChanged], counts[configDiffUnchanged])))
+		fmt.Println()
+
+		return nil
+	},
+}
+
+// runConfigMatrix renders every key across the environments of the project's pipeline
+func runConfigMatrix(client *api.Client, org, project, agentName, output string, showAll, showSecrets, force bool, displayValue func(key, value string) string) error {
+	pipeline, err := client.GetProjectDeploymentPipeline(org, project)
+	if err != nil {
+		return fmt.Errorf("failed to get deployment pipeline: %w", err)
+	}
+	envs := orderPipelineEnvironments(pipeline)
+	if len(envs) == 0 {
+		return fmt.Errorf("deployment pipeline %q has no environments", pipeline.Name)
+	}
+
+	// Revealing production secrets requires explicit confirmation
+	if showSecrets {
+		ok, err := confirmShowSecrets(client, org, envs, force)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+	}
+
+	// Fetch configuration for every environment
+	raw := make(map[string]map[string]string) // key -> env -> value
+	for _, env := range envs {
+		resp, err := client.GetAgentConfigurations(org, project, agentName, env)
+		if err != nil {
+			return fmt.Errorf("failed to get configuration for %s: %w", env, err)
+		}
+		for _, cfg := range resp.Configurations {
+			if raw[cfg.Key] == nil {
+				raw[cfg.Key] = make(map[string]string)
+			}
+			raw[cfg.Key][env] = cfg.Value
+		}
+	}
+
+	keys := make([]string, 0, len(raw))
+	for key := range raw {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	// Determine which keys differ across environments
+	var differs []string
+	differsSet := make(map[string]bool)
+	for _, key := range keys {
+		if configKeyDiffers(raw[key], envs) {
+			differs = append(differs, key)
+			differsSet[key] = true
+		}
+	}
+
+	// Mask values for display
+	values := make(map[string]map[string]string)
+	for _, key := range keys {
+		if !showAll && !differsSet[key] {
+			continue
+		}
+		values[key] = make(map[string]string)
+		for env, v := range raw[key] {
+			values[key][env] = displayValue(key, v)
+		}
+	}
+
+	// JSON output
+	if output == "json" {
+		result := configMatrixResult{
+			AgentName:    agentName,
+			Environments: envs,
+			Values:       values,
+			Differs:      differs,
+		}
+		if result.Differs == nil {
+			result.Differs = []string{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	}
+
+	title := fmt.Sprintf("%s Config Matrix for %s (%s)", ui.IconAgent, agentName, pipeline.Name)
+	fmt.Println(ui.TitleStyle.Render(title))
+	fmt.Println()
+
+	if len(values) == 0 {
+		fmt.Println(ui.RenderSuccess("Configuration is identical across all environments."))
+		return nil
+	}
+
+	// Build table data
+	headers := append([]string{"KEY"}, envs...)
+	headers = append(headers, "SAME")
+	var rows [][]string
+	for _, key := range keys {
+		if values[key] == nil {
+			continue
+		}
+		row := []string{key}
+		for _, env := range envs {
+			v, ok := values[key][env]
+			if !ok {
+				row = append(row, ui.MutedStyle.Render("(unset)"))
+				continue
+			}
+			row = append(row, v)
+		}
+		if differsSet[key] {
+			row = append(row, ui.ErrorStyle.Render(ui.IconError))
+		} else {
+			row = append(row, ui.SuccessStyle.Render(ui.IconSuccess))
+		}
+		rows = append(rows, row)
+	}
+	fmt.Println(ui.RenderTable(headers, rows))
+	fmt.Println()
+	fmt.Println(ui.MutedStyle.Render(fmt.Sprintf("  %d of %d keys differ across %d environments", len(differs), len(keys), len(envs))))
+	fmt.Println()
+
+	return nil
+}
+
+// diffConfigurations compares two sets of environment variables, sorted by key
+func diffConfigurations(from, to []api.EnvironmentVariable) []configDiffEntry {
+	fromMap := make(map[string]string, len(from))
+	for _, cfg := range from {
+		fromMap[cfg.Key] = cfg.Value
+	}
+	toMap := make(map[string]string, len(to))
+	for _, cfg := range to {
+		toMap[cfg.Key] = cfg.Value
+	}
+
+	var entries []configDiffEntry
+	for key, fromValue := range fromMap {
+		toValue, ok := toMap[key]
+		switch {
+		case !ok:
+			entries = append(entries, configDiffEntry{Key: key, Status: configDiffMissing, From: fromValue})
+		case toValue != fromValue:
+			entries = append(entries, configDiffEntry{Key: key, Status: configDiffChanged, From: fromValue, To: toValue})
+		default:
+			entries = append(entries, configDiffEntry{Key: key, Status: configDiffUnchanged, From: fromValue, To: toValue})
+		}
+	}
+	for key, toValue := range toMap {
+		if _, ok := fromMap[key]; !ok {
+			entries = append(entries, configDiffEntry{Key: key, Status: configDiffExtra, To: toValue})
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	return entries
+}
+
+// configKeyDiffers reports whether a key is unset somewhere or has differing values
+func configKeyDiffers(valuesByEnv map[string]string, envs []string) bool {
+	var first string
+	for i, env := range envs {
+		v, ok := valuesByEnv[env]
+		if !ok {
+			return true
+		}
+		if i == 0 {
+			first = v
+		} else if v != first {
+			return true
+		}
+	}
+	return false
+}
+
+// hashConfigValue returns a short SHA-256 digest of a value for comparison without disclosure
+func hashConfigValue(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return "sha256:" + hex.EncodeToString(sum[:])[:12]
+}
+
+// configDiffStatusCell returns a styled diff status
+func configDiffStatusCell(status string) string {
+	switch status {
+	case configDiffMissing:
+		return ui.ErrorStyle.Render(status)
+	case configDiffExtra:
+		return ui.InfoStyle.Render(status)
+	case configDiffChanged:
+		return ui.WarningStyle.Render(status)
+	default:
+		return ui.MutedStyle.Render(status)
+	}
+}
+
+func init() {
+	agentsConfigCmd.AddCommand(agentsConfigDiffCmd)
+
+	// Add flags for config diff command
+	agentsConfigDiffCmd.Flags().StringP("agent", "a", "", "Agent name (required)")
+	agentsConfigDiffCmd.Flags().String("from", "", "Source environment to compare from")
+	agentsConfigDiffCmd.Flags().String("to", "", "Target environment to compare to")
+	agentsConfigDiffCmd.Flags().Bool("matrix", false, "Compare every environment in the project's deployment pipeline")
+	agentsConfigDiffCmd.Flags().Bool("hash-only", false, "Show value hashes instead of values")
+	agentsConfigDiffCmd.Flags().Bool("show-secrets", false, "Show unmasked values for sensitive variables")
+	agentsConfigDiffCmd.Flags().Bool("all", false, "Include unchanged keys")
+	agentsConfigDiffCmd.Flags().BoolP("force", "f", false, "Skip the confirmation prompt when revealing production secrets")
+}
@@ -0,0 +1,339 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Kavirubc/wso2-amp-cli/internal/api"
+	"github.com/Kavirubc/wso2-amp-cli/internal/config"
+	"github.com/Kavirubc/wso2-amp-cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// minTokenValidity is how long a cached token must remain valid to be reused
+const minTokenValidity = time.Minute
+
+// defaultInvokeTimeout bounds a single request to an agent endpoint
+const defaultInvokeTimeout = 60 * time.Second
+
+// invokeResult is the JSON shape of agents invoke
+type invokeResult struct {
+	URL        string              `json:"url"`
+	Method     string              `json:"method"`
+	StatusCode int                 `json:"statusCode"`
+	Status     string              `json:"status"`
+	LatencyMs  int64               `json:"latencyMs"`
+	Headers    map[string][]string `json:"headers"`
+	Body       json.RawMessage     `json:"body,omitempty"`
+	Text       string              `json:"text,omitempty"`
+}
+
+// resolveAgentEndpoint finds the endpoint of an agent in an environment by name.
+// Without a name the agent must expose exactly one endpoint.
+func resolveAgentEndpoint(client *api.Client, org, project, agentName, envName, name string) (api.EndpointResponse, error) {
+	endpoints, err := client.GetAgentEndpoints(org, project, agentName, envName)
+	if err != nil {
+		return api.EndpointResponse{}, fmt.Errorf("failed to get endpoints: %w", err)
+	}
+	if len(endpoints) == 0 {
+		return api.EndpointResponse{}, fmt.Errorf("agent %q has no endpoints in environment %q", agentName, envName)
+	}
+
+	names := make([]string, len(endpoints))
+	for i, ep := range endpoints {
+		if name != "" && ep.EndpointName == name {
+			return ep, nil
+		}
+		names[i] = ep.EndpointName
+	}
+	if name != "" {
+		return api.EndpointResponse{}, fmt.Errorf("endpoint %q not found; available: %s", name, strings.Join(names, ", "))
+	}
+	if len(endpoints) > 1 {
+		return api.EndpointResponse{}, fmt.Errorf("agent %q has %d endpoints in %q; choose one with --endpoint: %s", agentName, len(endpoints), envName, strings.Join(names, ", "))
+	}
+	return endpoints[0], nil
+}
+
+// agentBearerToken returns a cached agent token, or mints and caches a new one
+func agentBearerToken(client *api.Client, org, project, agentName, expiresIn string, renew bool) (string, error) {
+	if !renew {
+		if cached, ok := config.GetCachedAgentToken(org, project, agentName, minTokenValidity); ok {
+			return cached.Token, nil
+		}
+	}
+	tokenResp, err := client.GenerateAgentToken(org, project, agentName, &api.TokenRequest{ExpiresIn: expiresIn})
+	if err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	cached := config.CachedToken{Token: tokenResp.Token, TokenType: tokenResp.TokenType, ExpiresAt: tokenResp.ExpiresAt}
+	if err := config.SaveAgentToken(org, project, agentName, cached); err != nil {
+		fmt.Fprintln(os.Stderr, ui.RenderWarning(fmt.Sprintf("could not cache token: %v", err)))
+	}
+	return tokenResp.Token, nil
+}
+
+// readInvokeBody builds the request body from --data, --data-file or --field.
+// --data-file - reads standard input. Fields are key=value for strings and
+// key:=value for raw JSON values.
+func readInvokeBody(data, dataFile string, fields []string) ([]byte, error) {
+	sources := 0
+	for _, set := range []bool{data != "", dataFile != "", len(fields) > 0} {
+		if set {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return nil, fmt.Errorf("use only one of --data, --data-file and --field")
+	}
+
+	var body []byte
+	switch {
+	case data != "":
+		body = []byte(data)
+	case dataFile == "-":
+		b, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read body from stdin: %w", err)
+		}
+		body = b
+	case dataFile != "":
+		b, err := os.ReadFile(dataFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read body file: %w", err)
+		}
+		body = b
+	case len(fields) > 0:
+		obj := make(map[string]json.RawMessage, len(fields))
+		for _, field := range fields {
+			if key, value, ok := strings.Cut(field, ":="); ok && !strings.Contains(key, "=") {
+				if !json.Valid([]byte(value)) {
+					return nil, fmt.Errorf("invalid JSON value in --field %q", field)
+				}
+				obj[key] = json.RawMessage(value)
+				continue
+			}
+			key, value, ok := strings.Cut(field, "=")
+			if !ok || key == "" {
+				return nil, fmt.Errorf("invalid --field %q: expected key=value or key:=json", field)
+			}
+			encoded, _ := json.Marshal(value)
+			obj[key] = encoded
+		}
+		return json.Marshal(obj)
+	default:
+		return nil, nil
+	}
+
+	if len(bytes.TrimSpace(body)) > 0 && !json.Valid(body) {
+		return nil, fmt.Errorf("request body is not valid JSON")
+	}
+	return body, nil
+}
+
+// joinEndpointPath appends a request path to an endpoint URL
+func joinEndpointPath(base, path string) string {
+	if path == "" {
+		return base
+	}
+	return strings.TrimRight(base, "/") + "/" + strings.TrimLeft(path, "/")
+}
+
+var agentsInvokeCmd = &cobra.Command{
+	Use:   "invoke",
+	Short: "Send a request to a deployed agent endpoint",
+	Long: `Send a request to a deployed agent and print the response with its status
+and latency.
+
+The endpoint URL is resolved from the agent's endpoints in the environment,
+and requests are authenticated with an agent token. Tokens are cached in
+~/.amp/tokens.json and reused until they are about to expire.
+
+The JSON body comes from --data, a file with --data-file (use - for stdin),
+or --field flags: key=value sets a string and key:=value sets a raw JSON value.
+
+Examples:
+  amp agents invoke --agent myagent --env dev --path /chat --data '{"message":"hello"}'
+  amp agents invoke --agent myagent --env dev --path /chat --field message=hello --field stream:=false
+  amp agents invoke --agent myagent --env dev --endpoint main --data-file request.json
+  echo '{"message":"hi"}' | amp agents invoke --agent myagent --env dev --path /chat --data-file -
+  amp agents invoke --agent myagent --env dev --method GET --path /health`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flags
+		org, _ := cmd.Flags().GetString("org")
+		project, _ := cmd.Flags().GetString("project")
+		agentName, _ := cmd.Flags().GetString("agent")
+		envName, _ := cmd.Flags().GetString("env")
+		endpointName, _ := cmd.Flags().GetString("endpoint")
+		path, _ := cmd.Flags().GetString("path")
+		method, _ := cmd.Flags().GetString("method")
+		data, _ := cmd.Flags().GetString("data")
+		dataFile, _ := cmd.Flags().GetString("data-file")
+		fields, _ := cmd.Flags().GetStringArray("field")
+		headers, _ := cmd.Flags().GetStringArray("header")
+		noAuth, _ := cmd.Flags().GetBool("no-auth")
+		newToken, _ := cmd.Flags().GetBool("new-token")
+		expiresIn, _ := cmd.Flags().GetString("token-expires-in")
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		output, _ := cmd.Flags().GetString("output")
+
+		// Use defaults from config if not provided
+		if org == "" {
+			org = config.GetDefaultOrg()
+		}
+		if project == "" {
+			project = config.GetDefaultProject()
+		}
+
+		// Validate required fields
+		if org == "" {
+			return fmt.Errorf("organization is required. Use --org flag or set default with: amp config set default_org <name>")
+		}
+		if project == "" {
+			return fmt.Errorf("project is required. Use --project flag or set default with: amp config set default_project <name>")
+		}
+		if agentName == "" {
+			return fmt.Errorf("agent name is required. Use --agent flag")
+		}
+		if envName == "" {
+			return fmt.Errorf("environment name is required. Use --env flag")
+		}
+
+		body, err := readInvokeBody(data, dataFile, fields)
+		if err != nil {
+			return err
+		}
+		method = strings.ToUpper(method)
+
+		// Create API client
+		client := api.NewClient(
+			config.GetAPIURL(),
+			config.GetAPIKeyHeader(),
+			config.GetAPIKeyValue(),
+		)
+
+		endpoint, err := resolveAgentEndpoint(client, org, project, agentName, envName, endpointName)
+		if err != nil {
+			return err
+		}
+		url := joinEndpointPath(endpoint.URL, path)
+
+		req, err := http.NewRequest(method, url, bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Accept", "application/json")
+		if len(body) > 0 {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		if !noAuth {
+			token, err := agentBearerToken(client, org, project, agentName, expiresIn, newToken)
+			if err != nil {
+				return err
+			}
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		for _, h := range headers {
+			name, value, ok := strings.Cut(h, ":")
+			if !ok {
+				return fmt.Errorf("invalid --header %q: expected 'Name: value'", h)
+			}
+			req.Header.Set(strings.TrimSpace(name), strings.TrimSpace(value))
+		}
+
+		// Send the request, timing until the full body has arrived
+		started := time.Now()
+		resp, err := (&http.Client{Timeout: timeout}).Do(req)
+		if err != nil {
+			return fmt.Errorf("request to %s failed: %w", url, err)
+		}
+		respBody, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		latency := time.Since(started)
+		if err != nil {
+			return fmt.Errorf("failed to read response: %w", err)
+		}
+
+		result := invokeResult{
+			URL:        url,
+			Method:     method,
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			LatencyMs:  latency.Milliseconds(),
+			Headers:    resp.Header,
+		}
+		if json.Valid(respBody) {
+			result.Body = respBody
+		} else {
+			result.Text = string(respBody)
+		}
+
+		// JSON output
+		if output == "json" {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(result); err != nil {
+				return err
+			}
+		} else {
+			printInvokeResult(result)
+		}
+
+		// A failing endpoint fails the command, so it can gate scripts
+		if resp.StatusCode >= 400 {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("agent returned %s", resp.Status)
+		}
+		return nil
+	},
+}
+
+// printInvokeResult prints the status line and the pretty-printed body
+func printInvokeResult(result invokeResult) {
+	status := ui.SuccessStyle.Render(result.Status)
+	if result.StatusCode >= 400 {
+		status = ui.ErrorStyle.Render(result.Status)
+	} else if result.StatusCode >= 300 {
+		status = ui.WarningStyle.Render(result.Status)
+	}
+	fmt.Printf("%s %s  %s  %s\n", ui.MutedStyle.Render(result.Method), result.URL, status, ui.MutedStyle.Render(fmt.Sprintf("%dms", result.LatencyMs)))
+	fmt.Println()
+
+	switch {
+	case len(result.Body) > 0:
+		var pretty bytes.Buffer
+		if err := json.Indent(&pretty, result.Body, "", "  "); err == nil {
+			fmt.Println(pretty.String())
+		} else {
+			fmt.Println(string(result.Body))
+		}
+	case result.Text != "":
+		fmt.Println(result.Text)
+	default:
+		fmt.Println(ui.MutedStyle.Render("(empty body)"))
+	}
+}
+
+func init() {
+	agentsCmd.AddCommand(agentsInvokeCmd)
+
+	agentsInvokeCmd.Flags().StringP("agent", "a", "", "Agent name (required)")
+	agentsInvokeCmd.Flags().StringP("env", "e", "", "Environment name (required)")
+	agentsInvokeCmd.Flags().String("endpoint", "", "Endpoint name (required when the agent has several)")
+	agentsInvokeCmd.Flags().String("path", "", "Path appended to the endpoint URL (e.g., /chat)")
+	agentsInvokeCmd.Flags().StringP("method", "X", http.MethodPost, "HTTP method")
+	agentsInvokeCmd.Flags().StringP("data", "d", "", "JSON request body")
+	agentsInvokeCmd.Flags().String("data-file", "", "Read the JSON request body from a file (- for stdin)")
+	agentsInvokeCmd.Flags().StringArrayP("field", "F", nil, "Body field as key=value (string) or key:=value (JSON) (repeatable)")
+	agentsInvokeCmd.Flags().StringArrayP("header", "H", nil, "Extra request header as 'Name: value' (repeatable)")
+	agentsInvokeCmd.Flags().Bool("no-auth", false, "Send the request without an agent token")
+	agentsInvokeCmd.Flags().Bool("new-token", false, "Mint a new token instead of reusing a cached one")
+	agentsInvokeCmd.Flags().String("token-expires-in", "", "Lifetime of a newly minted token (e.g., 1h)")
+	agentsInvokeCmd.Flags().Duration("timeout", defaultInvokeTimeout, "Request timeout")
+}
@@ -0,0 +1,256 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Kavirubc/wso2-amp-cli/internal/config"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+const invokeStubToken = "stub-agent-token"
+
+// invokeStub serves the platform API and an agent endpoint, and remembers the
+// last request the agent received
+type invokeStub struct {
+	srv *httptest.Server
+
+	mu         sync.Mutex
+	lastBody   string
+	lastAuth   string
+	lastMethod string
+	lastPath   string
+}
+
+func newInvokeStub(t *testing.T) *invokeStub {
+	t.Helper()
+	s := &invokeStub{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/orgs/o/projects/p/agents/alpha/endpoints", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]map[string]string{
+			{"url": s.srv.URL + "/agent", "endpointName": "main", "visibility": "Public"},
+		})
+	})
+	mux.HandleFunc("/api/orgs/o/projects/p/agents/alpha/token", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"token":     invokeStubToken,
+			"tokenType": "Bearer",
+			"expiresAt": time.Now().Add(time.Hour).Unix(),
+		})
+	})
+	mux.HandleFunc("/agent/", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		s.mu.Lock()
+		s.lastBody, s.lastAuth = string(body), r.Header.Get("Authorization")
+		s.lastMethod, s.lastPath = r.Method, r.URL.Path
+		s.mu.Unlock()
+
+		// Give the latency a lower bound the test can check
+		time.Sleep(20 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/agent/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+			io.WriteString(w, `{"error":"boom"}`)
+			return
+		}
+		io.WriteString(w, `{"reply":"hello"}`)
+	})
+	s.srv = httptest.NewServer(mux)
+	t.Cleanup(s.srv.Close)
+	return s
+}
+
+func (s *invokeStub) last() (method, path, body, auth string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastMethod, s.lastPath, s.lastBody, s.lastAuth
+}
+
+// resetCommandFlags restores flag defaults, since flag values outlive a
+// command run within one process
+func resetCommandFlags(flags *pflag.FlagSet) {
+	flags.VisitAll(func(f *pflag.Flag) {
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			sv.Replace(nil)
+		} else {
+			f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	})
+}
+
+// runInvoke runs amp agents invoke against the stub and returns its stdout
+func runInvoke(t *testing.T, s *invokeStub, stdin io.Reader, args ...string) (string, error) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	viper.Set(config.KeyAPIURL, s.srv.URL+"/api")
+	resetCommandFlags(agentsInvokeCmd.Flags())
+	resetCommandFlags(rootCmd.PersistentFlags())
+
+	if stdin != nil {
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatal(err)
+		}
+		go func() {
+			io.Copy(w, stdin)
+			w.Close()
+		}()
+		oldStdin := os.Stdin
+		os.Stdin = r
+		defer func() { os.Stdin = oldStdin }()
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldStdout := os.Stdout
+	os.Stdout = w
+	out := make(chan string)
+	go func() {
+		var b bytes.Buffer
+		io.Copy(&b, r)
+		out <- b.String()
+	}()
+
+	rootCmd.SetArgs(append([]string{"agents", "invoke", "--org", "o", "--project", "p", "--agent", "alpha", "--env", "dev"}, args...))
+	rootCmd.SetErr(io.Discard)
+	runErr := rootCmd.Execute()
+
+	w.Close()
+	os.Stdout = oldStdout
+	return <-out, runErr
+}
+
+func TestAgentsInvokeBodySources(t *testing.T) {
+	s := newInvokeStub(t)
+	file := filepath.Join(t.TempDir(), "request.json")
+	if err := os.WriteFile(file, []byte(`{"message":"from file"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		stdin io.Reader
+		args  []string
+		want  string
+	}{
+		{"data", nil, []string{"--data", `{"message":"hi"}`}, `{"message":"hi"}`},
+		{"fields", nil, []string{"--field", "message=hi", "--field", "stream:=false"}, `{"message":"hi","stream":false}`},
+		{"file", nil, []string{"--data-file", file}, `{"message":"from file"}`},
+		{"stdin", strings.NewReader(`{"message":"from stdin"}`), []string{"--data-file", "-"}, `{"message":"from stdin"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := runInvoke(t, s, tt.stdin, append([]string{"--path", "/chat"}, tt.args...)...); err != nil {
+				t.Fatalf("invoke failed: %v", err)
+			}
+			method, path, body, _ := s.last()
+			if method != http.MethodPost || path != "/agent/chat" {
+				t.Errorf("request = %s %s, want POST /agent/chat", method, path)
+			}
+			var got, want interface{}
+			if err := json.Unmarshal([]byte(body), &got); err != nil {
+				t.Fatalf("body %q is not JSON: %v", body, err)
+			}
+			json.Unmarshal([]byte(tt.want), &want)
+			gotJSON, _ := json.Marshal(got)
+			wantJSON, _ := json.Marshal(want)
+			if string(gotJSON) != string(wantJSON) {
+				t.Errorf("body = %s, want %s", gotJSON, wantJSON)
+			}
+		})
+	}
+}
+
+func TestAgentsInvokeAuthorization(t *testing.T) {
+	s := newInvokeStub(t)
+
+	if _, err := runInvoke(t, s, nil, "--path", "/chat", "--data", `{}`); err != nil {
+		t.Fatalf("invoke failed: %v", err)
+	}
+	if _, _, _, auth := s.last(); auth != "Bearer "+invokeStubToken {
+		t.Errorf("Authorization = %q, want the minted agent token", auth)
+	}
+
+	if _, err := runInvoke(t, s, nil, "--path", "/chat", "--no-auth"); err != nil {
+		t.Fatalf("invoke failed: %v", err)
+	}
+	if _, _, _, auth := s.last(); auth != "" {
+		t.Errorf("Authorization = %q with --no-auth, want none", auth)
+	}
+}
+
+func TestAgentsInvokeOutput(t *testing.T) {
+	s := newInvokeStub(t)
+
+	out, err := runInvoke(t, s, nil, "--path", "/chat", "--data", `{}`)
+	if err != nil {
+		t.Fatalf("invoke failed: %v", err)
+	}
+	for _, want := range []string{"POST " + s.srv.URL + "/agent/chat", "200 OK", "ms", `"reply": "hello"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output does not contain %q:\n%s", want, out)
+		}
+	}
+
+	out, err = runInvoke(t, s, nil, "--path", "/chat", "--data", `{}`, "--output", "json")
+	if err != nil {
+		t.Fatalf("invoke failed: %v", err)
+	}
+	var result invokeResult
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out)
+	}
+	if result.StatusCode != http.StatusOK || result.Method != http.MethodPost {
+		t.Errorf("result = %d %s, want 200 POST", result.StatusCode, result.Method)
+	}
+	if result.LatencyMs < 20 {
+		t.Errorf("latencyMs = %d, want at least the stub's 20ms delay", result.LatencyMs)
+	}
+	var body bytes.Buffer
+	if err := json.Compact(&body, result.Body); err != nil || body.String() != `{"reply":"hello"}` {
+		t.Errorf("body = %s, want the agent's reply", result.Body)
+	}
+}
+
+func TestAgentsInvokeErrorStatus(t *testing.T) {
+	s := newInvokeStub(t)
+
+	out, err := runInvoke(t, s, nil, "--path", "/fail", "--data", `{}`)
+	if err == nil || !strings.Contains(err.Error(), "500") {
+		t.Fatalf("err = %v, want the agent's 500 status", err)
+	}
+	if !strings.Contains(out, "500 Internal Server Error") || !strings.Contains(out, `"error": "boom"`) {
+		t.Errorf("output should still show the failed response:\n%s", out)
+	}
+}
+
+func TestAgentsInvokeHelpers(t *testing.T) {
+	if _, err := readInvokeBody(`{}`, "", []string{"a=b"}); err == nil {
+		t.Error("readInvokeBody accepted --data together with --field")
+	}
+	if _, err := readInvokeBody(`{not json`, "", nil); err == nil {
+		t.Error("readInvokeBody accepted an invalid JSON body")
+	}
+	for _, tt := range []struct{ base, path, want string }{
+		{"http://h/agent", "", "http://h/agent"},
+		{"http://h/agent/", "/chat", "http://h/agent/chat"},
+		{"http://h/agent", "chat", "http://h/agent/chat"},
+	} {
+		if got := joinEndpointPath(tt.base, tt.path); got != tt.want {
+			t.Errorf("joinEndpointPath(%q, %q) = %q, want %q", tt.base, tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,362 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Kavirubc/wso2-amp-cli/internal/api"
+	"github.com/Kavirubc/wso2-amp-cli/internal/config"
+	"github.com/Kavirubc/wso2-amp-cli/internal/ui"
+	"github.com/Kavirubc/wso2-amp-cli/internal/util"
+	"github.com/spf13/cobra"
+)
+
+// Right-sizing statuses for a resource
+const (
+	sizingOK              = "ok"
+	sizingOverProvisioned = "over-provisioned"
+	sizingUnderRequested  = "under-requested"
+	sizingThrottlingRisk  = "throttling-risk"
+	sizingOOMRisk         = "oom-risk"
+	sizingNoData          = "no-data"
+)
+
+// Thresholds used to classify usage against the current request and limit
+const (
+	overProvisionedRatio = 0.5 // p95 below half of the request
+	limitRiskRatio       = 0.9 // max at or above 90% of the limit
+)
+
+// Smallest values and rounding steps for suggestions
+const (
+	minCPURequest    = 0.01 // 10m
+	cpuStep          = 0.01
+	minMemoryRequest = 32 * 1024 * 1024
+	memoryStep       = 16 * 1024 * 1024
+)
+
+// resourceRecommendation is the suggestion for one resource of one agent
+type resourceRecommendation struct {
+	Agent            string   `json:"agent"`
+	Resource         string   `json:"resource"`
+	P95              float64  `json:"p95"`
+	Max              float64  `json:"max"`
+	CurrentRequest   *float64 `json:"currentRequest,omitempty"`
+	CurrentLimit     *float64 `json:"currentLimit,omitempty"`
+	SuggestedRequest float64  `json:"suggestedRequest"`
+	SuggestedLimit   float64  `json:"suggestedLimit"`
+	Status           string   `json:"status"`
+	Reason           string   `json:"reason,omitempty"`
+}
+
+// resourceRecommendations is the JSON shape of recommend-resources
+type resourceRecommendations struct {
+	Project         string                   `json:"project"`
+	Environment     string                   `json:"environment"`
+	StartTime       string                   `json:"startTime"`
+	EndTime         string                   `json:"endTime"`
+	RequestHeadroom float64                  `json:"requestHeadroomPercent"`
+	LimitHeadroom   float64                  `json:"limitHeadroomPercent"`
+	Recommendations []resourceRecommendation `json:"recommendations"`
+	Errors          map[string]string        `json:"errors,omitempty"`
+}
+
+// recommendResources suggests a request and limit for one resource of an agent.
+// The request covers p95 usage and the limit covers peak usage, each with headroom.
+func recommendResources(agent string, series metricSeries, requestHeadroom, limitHeadroom float64) resourceRecommendation {
+	stats := statsForSeries(series)
+	rec := resourceRecommendation{Agent: agent, Resource: strings.ToLower(series.Name)}
+	if stats.hasRequest {
+		v := stats.Request.Last
+		rec.CurrentRequest = &v
+	}
+	if stats.hasLimit {
+		v := stats.Limit.Last
+		rec.CurrentLimit = &v
+	}
+	if !stats.hasUsage {
+		rec.Status = sizingNoData
+		rec.Reason = "no usage data in the window"
+		return rec
+	}
+	rec.P95 = stats.Usage.P95
+	rec.Max = stats.Usage.Max
+
+	minimum, step := minCPURequest, cpuStep
+	if rec.Resource != "cpu" {
+		minimum, step = minMemoryRequest, memoryStep
+	}
+	rec.SuggestedRequest = roundUpTo(math.Max(rec.P95*(1+requestHeadroom/100), minimum), step)
+	rec.SuggestedLimit = roundUpTo(math.Max(rec.Max*(1+limitHeadroom/100), rec.SuggestedRequest), step)
+
+	rec.Status, rec.Reason = classifyUsage(rec, series.Format)
+	return rec
+}
+
+// classifyUsage flags a resource whose usage is far from its current request or limit
+func classifyUsage(rec resourceRecommendation, format func(float64) string) (string, string) {
+	if rec.CurrentLimit != nil && *rec.CurrentLimit > 0 && rec.Max >= *rec.CurrentLimit*limitRiskRatio {
+		status := sizingThrottlingRisk
+		if rec.Resource != "cpu" {
+			status = sizingOOMRisk
+		}
+		return status, fmt.Sprintf("peak %s is %.0f%% of limit %s", format(rec.Max), rec.Max / *rec.CurrentLimit * 100, format(*rec.CurrentLimit))
+	}
+	if rec.CurrentRequest != nil && *rec.CurrentRequest > 0 {
+		ratio := rec.P95 / *rec.CurrentRequest
+		if ratio > 1 {
+			return sizingUnderRequested, fmt.Sprintf("p95 %s exceeds request %s", format(rec.P95), format(*rec.CurrentRequest))
+		}
+		if ratio < overProvisionedRatio {
+			return sizingOverProvisioned, fmt.Sprintf("p95 %s is %.0f%% of request %s", format(rec.P95), ratio*100, format(*rec.CurrentRequest))
+		}
+	}
+	return sizingOK, ""
+}
+
+// roundUpTo rounds v up to a multiple of step
+func roundUpTo(v, step float64) float64 {
+	return math.Ceil(v/step-1e-9) * step
+}
+
+var agentsRecommendResourcesCmd = &cobra.Command{
+	Use:   "recommend-resources",
+	Short: "Suggest CPU and memory requests and limits from usage history",
+	Long: `Analyse historical CPU and memory usage against the configured requests
+and limits, and suggest new values with headroom. The request covers p95
+usage and the limit covers peak usage.
+
+Agents are flagged as over-provisioned when p95 usage is below half of the
+request, under-requested when p95 usage exceeds the request, and at risk of
+CPU throttling or OOM kills when peak usage reaches 90% of the limit.
+
+Without --agent, every agent in the project is analysed.
+
+Examples:
+  amp agents recommend-resources --env production --since 7d
+  amp agents recommend-resources --env production --agent myagent
+  amp agents recommend-resources --env dev --request-headroom 30 --limit-headroom 100
+  amp agents recommend-resources --env production --only-flagged --output json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flags
+		org, _ := cmd.Flags().GetString("org")
+		project, _ := cmd.Flags().GetString("project")
+		agentName, _ := cmd.Flags().GetString("agent")
+		envName, _ := cmd.Flags().GetString("env")
+		since, _ := cmd.Flags().GetString("since")
+		requestHeadroom, _ := cmd.Flags().GetFloat64("request-headroom")
+		limitHeadroom, _ := cmd.Flags().GetFloat64("limit-headroom")
+		onlyFlagged, _ := cmd.Flags().GetBool("only-flagged")
+		concurrency, _ := cmd.Flags().GetInt("concurrency")
+		output, _ := cmd.Flags().GetString("output")
+
+		// Use defaults from config if not provided
+		if org == "" {
+			org = config.GetDefaultOrg()
+		}
+		if project == "" {
+			project = config.GetDefaultProject()
+		}
+
+		// Validate required fields
+		if org == "" {
+			return fmt.Errorf("organization is required. Use --org flag or set default with: amp config set default_org <name>")
+		}
+		if project == "" {
+			return fmt.Errorf("project is required. Use --project flag or set default with: amp config set default_project <name>")
+		}
+		if envName == "" {
+			return fmt.Errorf("environment name is required. Use --env flag")
+		}
+		if requestHeadroom < 0 || limitHeadroom < 0 {
+			return fmt.Errorf("--request-headroom and --limit-headroom must not be negative")
+		}
+
+		start, err := util.ParseSinceDuration(since)
+		if err != nil {
+			return fmt.Errorf("invalid --since value: %w", err)
+		}
+		req := api.MetricsFilterRequest{
+			EnvironmentName: envName,
+			StartTime:       start.Format(time.RFC3339),
+			EndTime:         time.Now().Format(time.RFC3339),
+		}
+
+		// Create API client
+		client := api.NewClient(
+			config.GetAPIURL(),
+			config.GetAPIKeyHeader(),
+			config.GetAPIKeyValue(),
+		)
+
+		agents := []string{agentName}
+		if agentName == "" {
+			agents, err = listAllAgentNames(client, org, project)
+			if err != nil {
+				return fmt.Errorf("failed to list agents: %w", err)
+			}
+		}
+
+		// Fetch metrics for every agent
+		perAgent := make([][]resourceRecommendation, len(agents))
+		fetchErrs := make([]error, len(agents))
+		runBounded(len(agents), concurrency, func(i int) {
+			metrics, err := client.GetAgentMetrics(org, project, agents[i], req)
+			if err != nil {
+				fetchErrs[i] = err
+				return
+			}
+			for _, series := range agentMetricSeries(metrics) {
+				perAgent[i] = append(perAgent[i], recommendResources(agents[i], series, requestHeadroom, limitHeadroom))
+			}
+		})
+
+		result := resourceRecommendations{
+			Project:         project,
+			Environment:     envName,
+			StartTime:       req.StartTime,
+			EndTime:         req.EndTime,
+			RequestHeadroom: requestHeadroom,
+			LimitHeadroom:   limitHeadroom,
+			Recommendations: []resourceRecommendation{},
+		}
+		for i, recs := range perAgent {
+			if fetchErrs[i] != nil {
+				if result.Errors == nil {
+					result.Errors = make(map[string]string)
+				}
+				result.Errors[agents[i]] = fetchErrs[i].Error()
+				continue
+			}
+			for _, rec := range recs {
+				if onlyFlagged && (rec.Status == sizingOK || rec.Status == sizingNoData) {
+					continue
+				}
+				result.Recommendations = append(result.Recommendations, rec)
+			}
+		}
+		sort.SliceStable(result.Recommendations, func(i, j int) bool {
+			return result.Recommendations[i].Agent < result.Recommendations[j].Agent
+		})
+
+		// A single agent that cannot be fetched is an error
+		if agentName != "" && fetchErrs[0] != nil {
+			return fmt.Errorf("failed to get metrics: %w", fetchErrs[0])
+		}
+
+		// JSON output
+		if output == "json" {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(result)
+		}
+
+		printResourceRecommendations(result)
+		return nil
+	},
+}
+
+// printResourceRecommendations renders the recommendations table
+func printResourceRecommendations(result resourceRecommendations) {
+	fmt.Println(ui.TitleStyle.Render(fmt.Sprintf("%s Resource Recommendations: %s (%s)", ui.IconMetrics, result.Project, result.Environment)))
+	fmt.Println()
+	fmt.Printf("  %s  %s - %s\n", ui.KeyStyle.Render("Time Range:"), ui.FormatMetricTimestamp(result.StartTime), ui.FormatMetricTimestamp(result.EndTime))
+	fmt.Printf("  %s  request p95 +%.0f%%, limit peak +%.0f%%\n", ui.KeyStyle.Render("Headroom:"), result.RequestHeadroom, result.LimitHeadroom)
+	fmt.Println()
+
+	if len(result.Recommendations) == 0 {
+		fmt.Println(ui.RenderInfo("No recommendations for the specified criteria."))
+	} else {
+		headers := []string{"AGENT", "RESOURCE", "P95", "PEAK", "REQUEST", "LIMIT", "SUGGESTED REQUEST", "SUGGESTED LIMIT", "STATUS"}
+		rows := make([][]string, len(result.Recommendations))
+		flagged := 0
+		for i, rec := range result.Recommendations {
+			format := ui.FormatMemoryValue
+			if rec.Resource == "cpu" {
+				format = ui.FormatCPUValue
+			}
+			suggestedRequest, suggestedLimit := "-", "-"
+			p95, peak := "-", "-"
+			if rec.Status != sizingNoData {
+				p95, peak = format(rec.P95), format(rec.Max)
+				suggestedRequest, suggestedLimit = format(rec.SuggestedRequest), format(rec.SuggestedLimit)
+			}
+			rows[i] = []string{
+				rec.Agent,
+				rec.Resource,
+				p95,
+				peak,
+				formatOptionalMetric(rec.CurrentRequest, format),
+				formatOptionalMetric(rec.CurrentLimit, format),
+				suggestedRequest,
+				suggestedLimit,
+				formatSizingStatus(rec.Status),
+			}
+			if rec.Status != sizingOK && rec.Status != sizingNoData {
+				flagged++
+			}
+		}
+		fmt.Println(ui.RenderTable(headers, rows))
+
+		// Explain each flag
+		if flagged > 0 {
+			fmt.Println()
+			fmt.Println(ui.SectionStyle.Render("  Findings:"))
+			for _, rec := range result.Recommendations {
+				if rec.Reason != "" && rec.Status != sizingNoData {
+					fmt.Printf("  %s %s: %s\n", formatSizingStatus(rec.Status), rec.Agent+"/"+rec.Resource, rec.Reason)
+				}
+			}
+		}
+	}
+
+	if len(result.Errors) > 0 {
+		fmt.Println()
+		names := make([]string, 0, len(result.Errors))
+		for name := range result.Errors {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Println(ui.RenderWarning(fmt.Sprintf("%s: %s", name, result.Errors[name])))
+		}
+	}
+}
+
+// formatOptionalMetric formats a value that may be unset
+func formatOptionalMetric(v *float64, format func(float64) string) string {
+	if v == nil {
+		return "-"
+	}
+	return format(*v)
+}
+
+// formatSizingStatus colours a right-sizing status
+func formatSizingStatus(status string) string {
+	switch status {
+	case sizingOK:
+		return ui.SuccessStyle.Render(status)
+	case sizingThrottlingRisk, sizingOOMRisk:
+		return ui.ErrorStyle.Render(status)
+	case sizingOverProvisioned, sizingUnderRequested:
+		return ui.WarningStyle.Render(status)
+	}
+	return ui.MutedStyle.Render(status)
+}
+
+func init() {
+	agentsCmd.AddCommand(agentsRecommendResourcesCmd)
+
+	agentsRecommendResourcesCmd.Flags().StringP("agent", "a", "", "Agent name (default: all agents in the project)")
+	agentsRecommendResourcesCmd.Flags().StringP("env", "e", "", "Environment name (required)")
+	agentsRecommendResourcesCmd.Flags().String("since", "7d", "Usage history to analyse (e.g., 24h, 7d)")
+	agentsRecommendResourcesCmd.Flags().Float64("request-headroom", 20, "Percent added to p95 usage for the suggested request")
+	agentsRecommendResourcesCmd.Flags().Float64("limit-headroom", 50, "Percent added to peak usage for the suggested limit")
+	agentsRecommendResourcesCmd.Flags().Bool("only-flagged", false, "Show only over-provisioned, under-requested and at-risk resources")
+	agentsRecommendResourcesCmd.Flags().Int("concurrency", defaultLogsConcurrency, "Number of agents queried at once")
+}
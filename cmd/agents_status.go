@@ -0,0 +1,457 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Kavirubc/wso2-amp-cli/internal/api"
+	"github.com/Kavirubc/wso2-amp-cli/internal/config"
+	"github.com/Kavirubc/wso2-amp-cli/internal/ui"
+	"github.com/Kavirubc/wso2-amp-cli/internal/util"
+	"github.com/spf13/cobra"
+)
+
+// Health verdicts, from best to worst
+const (
+	healthHealthy   = "healthy"
+	healthDegraded  = "degraded"
+	healthUnhealthy = "unhealthy"
+	healthUnknown   = "unknown"
+)
+
+// Trace error rates at which an environment is degraded or unhealthy
+const (
+	degradedErrorRate  = 0.05
+	unhealthyErrorRate = 0.25
+)
+
+// Maximum traces and error logs sampled per environment
+const (
+	statusTraceLimit = 1000
+	statusLogLimit   = 1000
+)
+
+// environmentStatus is the health of an agent in one environment
+type environmentStatus struct {
+	Environment  string                   `json:"environment"`
+	DisplayName  string                   `json:"displayName,omitempty"`
+	DeployStatus string                   `json:"deploymentStatus"`
+	Image        string                   `json:"image,omitempty"`
+	LastDeployed *time.Time               `json:"lastDeployed,omitempty"`
+	Endpoints    []api.DeploymentEndpoint `json:"endpoints,omitempty"`
+	TraceCount   int                      `json:"traceCount"`
+	TraceErrors  int                      `json:"traceErrors"`
+	ErrorRate    float64                  `json:"errorRate"`
+	P95LatencyMs float64                  `json:"p95LatencyMs"`
+	ErrorLogs    int                      `json:"errorLogs"`
+	Health       string                   `json:"health"`
+	Reasons      []string                 `json:"reasons,omitempty"`
+	Errors       []string                 `json:"errors,omitempty"`
+}
+
+// agentStatusReport is the JSON shape of agents status
+type agentStatusReport struct {
+	Agent        string              `json:"agent"`
+	Project      string              `json:"project"`
+	AgentStatus  string              `json:"agentStatus,omitempty"`
+	Since        string              `json:"since"`
+	LatestBuild  *api.BuildResponse  `json:"latestBuild,omitempty"`
+	Environments []environmentStatus `json:"environments"`
+	Health       string              `json:"health"`
+	Reasons      []string            `json:"reasons,omitempty"`
+	Errors       []string            `json:"errors,omitempty"`
+}
+
+// healthRank orders verdicts so the worst can be picked
+func healthRank(health string) int {
+	switch health {
+	case healthHealthy:
+		return 0
+	case healthUnknown:
+		return 1
+	case healthDegraded:
+		return 2
+	}
+	return 3
+}
+
+// worseHealth returns the worse of two verdicts
+func worseHealth(a, b string) string {
+	if healthRank(b) > healthRank(a) {
+		return b
+	}
+	return a
+}
+
+// summarizeTraces sets the trace count, error rate and p95 latency of an environment
+func summarizeTraces(env *environmentStatus, traces []api.Trace) {
+	env.TraceCount = len(traces)
+	if len(traces) == 0 {
+		return
+	}
+	durations := make([]float64, len(traces))
+	for i, t := range traces {
+		if t.Status != nil && t.Status.ErrorCount > 0 {
+			env.TraceErrors++
+		}
+		durations[i] = float64(t.DurationInNanos) / float64(time.Millisecond)
+	}
+	sort.Float64s(durations)
+	env.ErrorRate = float64(env.TraceErrors) / float64(len(traces))
+	env.P95LatencyMs = percentile(durations, 95)
+}
+
+// judgeEnvironment sets the health verdict of an environment
+func judgeEnvironment(env *environmentStatus) {
+	env.Health = healthHealthy
+	switch strings.ToLower(env.DeployStatus) {
+	case "active", "running", "healthy", "success":
+	case "failed", "error", "inactive", "stopped":
+		env.Health = healthUnhealthy
+		env.Reasons = append(env.Reasons, "deployment is "+env.DeployStatus)
+	default:
+		env.Health = healthDegraded
+		env.Reasons = append(env.Reasons, "deployment is "+valueOrDefault(env.DeployStatus, "in an unknown state"))
+	}
+	switch {
+	case env.ErrorRate >= unhealthyErrorRate:
+		env.Health = worseHealth(env.Health, healthUnhealthy)
+		env.Reasons = append(env.Reasons, fmt.Sprintf("%.0f%% of traces have errors", env.ErrorRate*100))
+	case env.ErrorRate >= degradedErrorRate:
+		env.Health = worseHealth(env.Health, healthDegraded)
+		env.Reasons = append(env.Reasons, fmt.Sprintf("%.0f%% of traces have errors", env.ErrorRate*100))
+	}
+	if env.ErrorLogs > 0 {
+		env.Health = worseHealth(env.Health, healthDegraded)
+		env.Reasons = append(env.Reasons, fmt.Sprintf("%d ERROR log entries", env.ErrorLogs))
+	}
+	if len(env.Errors) > 0 && env.Health == healthHealthy {
+		env.Health = healthUnknown
+	}
+}
+
+var agentsStatusCmd = &cobra.Command{
+	Use:   "status <name>",
+	Short: "Show the health of an agent across environments",
+	Long: `Show one health overview of an agent: the latest build, and for each
+environment the deployed image, deployment status, endpoints, trace error
+rate, p95 latency and ERROR log count over the --since window.
+
+The overall verdict is the worst of the environments:
+  healthy    deployment active, few trace errors and no ERROR logs
+  degraded   deployment in progress, 5% or more of traces with errors,
+             ERROR logs, or a failed latest build
+  unhealthy  deployment failed, or 25% or more of traces with errors
+
+Examples:
+  amp agents status myagent
+  amp agents status myagent --since 24h
+  amp agents status myagent --env production --output json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		agentName := args[0]
+
+		// Get flags
+		org, _ := cmd.Flags().GetString("org")
+		project, _ := cmd.Flags().GetString("project")
+		envFilter, _ := cmd.Flags().GetString("env")
+		since, _ := cmd.Flags().GetString("since")
+		concurrency, _ := cmd.Flags().GetInt("concurrency")
+		output, _ := cmd.Flags().GetString("output")
+
+		// Use defaults from config if not provided
+		if org == "" {
+			org = config.GetDefaultOrg()
+		}
+		if project == "" {
+			project = config.GetDefaultProject()
+		}
+
+		// Validate required fields
+		if org == "" {
+			return fmt.Errorf("organization is required. Use --org flag or set default with: amp config set default_org <name>")
+		}
+		if project == "" {
+			return fmt.Errorf("project is required. Use --project flag or set default with: amp config set default_project <name>")
+		}
+
+		start, err := util.ParseSinceDuration(since)
+		if err != nil {
+			return fmt.Errorf("invalid --since value: %w", err)
+		}
+		end := time.Now()
+
+		// Create API client
+		client := api.NewClient(
+			config.GetAPIURL(),
+			config.GetAPIKeyHeader(),
+			config.GetAPIKeyValue(),
+		)
+
+		// Fetch the agent, latest build, deployments and pipeline at once
+		var (
+			wg          sync.WaitGroup
+			agent       *api.AgentResponse
+			builds      []api.BuildResponse
+			deployments map[string]api.DeploymentDetails
+			pipeline    *api.DeploymentPipelineResponse
+			agentErr    error
+			buildsErr   error
+			deployErr   error
+		)
+		wg.Add(4)
+		go func() {
+			defer wg.Done()
+			agent, agentErr = client.GetAgent(org, project, agentName)
+		}()
+		go func() {
+			defer wg.Done()
+			builds, _, buildsErr = client.ListBuilds(org, project, agentName, api.ListOptions{Limit: 1})
+		}()
+		go func() {
+			defer wg.Done()
+			deployments, deployErr = client.GetDeploymentsMap(org, project, agentName)
+		}()
+		go func() {
+			defer wg.Done()
+			// The pipeline only orders environments, so failures are ignored
+			pipeline, _ = client.GetProjectDeploymentPipeline(org, project)
+		}()
+		wg.Wait()
+
+		if agentErr != nil {
+			return fmt.Errorf("failed to get agent: %w", agentErr)
+		}
+		if deployErr != nil {
+			return fmt.Errorf("failed to list deployments: %w", deployErr)
+		}
+
+		report := agentStatusReport{
+			Agent:        agent.Name,
+			Project:      project,
+			AgentStatus:  agent.Status,
+			Since:        start.Format(time.RFC3339),
+			Environments: []environmentStatus{},
+			Health:       healthHealthy,
+		}
+		if buildsErr != nil {
+			report.Errors = append(report.Errors, "builds: "+buildsErr.Error())
+		} else if len(builds) > 0 {
+			report.LatestBuild = &builds[0]
+		}
+
+		// Environments in promotion order, then any others alphabetically
+		envNames := orderedDeploymentEnvironments(deployments, pipeline)
+		if envFilter != "" {
+			if _, ok := deployments[envFilter]; !ok {
+				return fmt.Errorf("agent %q is not deployed to environment %q", agentName, envFilter)
+			}
+			envNames = []string{envFilter}
+		}
+
+		// Fetch traces and error logs for each environment
+		envs := make([]environmentStatus, len(envNames))
+		runBounded(len(envNames), concurrency, func(i int) {
+			d := deployments[envNames[i]]
+			env := environmentStatus{
+				Environment:  envNames[i],
+				DisplayName:  d.EnvironmentDisplayName,
+				DeployStatus: d.Status,
+				Image:        d.ImageID,
+				LastDeployed: d.LastDeployed,
+				Endpoints:    d.Endpoints,
+			}
+
+			traces, err := client.ListTraces(org, project, agentName, api.TraceListOptions{
+				Environment: envNames[i],
+				StartTime:   start.Format(time.RFC3339),
+				EndTime:     end.Format(time.RFC3339),
+				Limit:       statusTraceLimit,
+			})
+			if err != nil {
+				env.Errors = append(env.Errors, "traces: "+err.Error())
+			} else {
+				summarizeTraces(&env, traces.Traces)
+			}
+
+			logs, err := client.GetAgentRuntimeLogs(org, project, agentName, api.RuntimeLogRequest{
+				EnvironmentName: envNames[i],
+				StartTime:       start.Format(time.RFC3339),
+				EndTime:         end.Format(time.RFC3339),
+				Limit:           statusLogLimit,
+				LogLevels:       []string{"ERROR"},
+			})
+			if err != nil {
+				env.Errors = append(env.Errors, "logs: "+err.Error())
+			} else {
+				env.ErrorLogs = max(logs.TotalCount, len(logs.Logs))
+			}
+
+			judgeEnvironment(&env)
+			envs[i] = env
+		})
+		report.Environments = envs
+
+		// Overall verdict
+		if report.LatestBuild != nil && buildOutcome(report.LatestBuild.Status) == buildOutcomeFailed {
+			report.Health = healthDegraded
+			report.Reasons = append(report.Reasons, fmt.Sprintf("latest build %s failed", report.LatestBuild.Name))
+		}
+		for _, env := range envs {
+			report.Health = worseHealth(report.Health, env.Health)
+			for _, reason := range env.Reasons {
+				report.Reasons = append(report.Reasons, env.Environment+": "+reason)
+			}
+		}
+		if len(envs) == 0 {
+			report.Health = worseHealth(report.Health, healthUnknown)
+			report.Reasons = append(report.Reasons, "not deployed to any environment")
+		}
+
+		// JSON output
+		if output == "json" {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(report)
+		}
+
+		printAgentStatus(report)
+		return nil
+	},
+}
+
+// orderedDeploymentEnvironments returns the deployed environments in pipeline order
+func orderedDeploymentEnvironments(deployments map[string]api.DeploymentDetails, pipeline *api.DeploymentPipelineResponse) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, env := range orderPipelineEnvironments(pipeline) {
+		if _, ok := deployments[env]; ok {
+			names = append(names, env)
+			seen[env] = true
+		}
+	}
+	var rest []string
+	for env := range deployments {
+		if !seen[env] {
+			rest = append(rest, env)
+		}
+	}
+	sort.Strings(rest)
+	return append(names, rest...)
+}
+
+// printAgentStatus renders the status report
+func printAgentStatus(report agentStatusReport) {
+	fmt.Println(ui.TitleStyle.Render(fmt.Sprintf("%s Status: %s", ui.IconAgent, report.Agent)))
+	fmt.Println()
+	printAgentRow("Health:", formatHealth(report.Health))
+	printAgentRow("Agent Status:", ui.StatusCell(report.AgentStatus))
+	if b := report.LatestBuild; b != nil {
+		printAgentRow("Latest Build:", fmt.Sprintf("%s  %s  %s  %s", b.Name, truncateCommit(b.CommitID), formatBuildStatus(b.Status), b.StartedAt.Local().Format("2006-01-02 15:04:05")))
+	} else {
+		printAgentRow("Latest Build:", ui.MutedStyle.Render("(none)"))
+	}
+	printAgentRow("Window:", "since "+ui.FormatMetricTimestamp(report.Since))
+	fmt.Println()
+
+	if len(report.Environments) > 0 {
+		headers := []string{"ENVIRONMENT", "HEALTH", "DEPLOYMENT", "IMAGE", "LAST DEPLOYED", "TRACES", "ERROR RATE", "P95", "ERROR LOGS"}
+		rows := make([][]string, len(report.Environments))
+		for i, env := range report.Environments {
+			lastDeployed := "-"
+			if env.LastDeployed != nil {
+				lastDeployed = env.LastDeployed.Local().Format("2006-01-02 15:04:05")
+			}
+			errorRate, p95 := "-", "-"
+			if env.TraceCount > 0 {
+				errorRate = fmt.Sprintf("%.1f%%", env.ErrorRate*100)
+				p95 = ui.FormatNanosDuration(int64(env.P95LatencyMs * float64(time.Millisecond)))
+			}
+			rows[i] = []string{
+				valueOrDefault(env.DisplayName, env.Environment),
+				formatHealth(env.Health),
+				ui.StatusCell(env.DeployStatus),
+				truncateImageID(env.Image),
+				lastDeployed,
+				fmt.Sprintf("%d", env.TraceCount),
+				errorRate,
+				p95,
+				fmt.Sprintf("%d", env.ErrorLogs),
+			}
+		}
+		fmt.Println(ui.RenderTable(headers, rows))
+
+		// Endpoints per environment
+		fmt.Println()
+		fmt.Println(ui.SubtitleStyle.Render("  Endpoints:"))
+		for _, env := range report.Environments {
+			if len(env.Endpoints) == 0 {
+				fmt.Printf("    %s  %s\n", ui.KeyStyle.Render(env.Environment+":"), ui.MutedStyle.Render("(none)"))
+				continue
+			}
+			for _, ep := range env.Endpoints {
+				fmt.Printf("    %s  %s %s\n", ui.KeyStyle.Render(env.Environment+":"), ep.URL, ui.MutedStyle.Render("("+ep.Visibility+")"))
+			}
+		}
+	}
+
+	if len(report.Reasons) > 0 {
+		fmt.Println()
+		fmt.Println(ui.SubtitleStyle.Render("  Findings:"))
+		for _, reason := range report.Reasons {
+			fmt.Printf("    • %s\n", reason)
+		}
+	}
+
+	var errs []string
+	errs = append(errs, report.Errors...)
+	for _, env := range report.Environments {
+		for _, e := range env.Errors {
+			errs = append(errs, env.Environment+" "+e)
+		}
+	}
+	if len(errs) > 0 {
+		fmt.Println()
+		for _, e := range errs {
+			fmt.Println(ui.RenderWarning(e))
+		}
+	}
+	fmt.Println()
+}
+
+// formatHealth colours a health verdict
+func formatHealth(health string) string {
+	switch health {
+	case healthHealthy:
+		return ui.SuccessStyle.Render(health)
+	case healthDegraded:
+		return ui.WarningStyle.Render(health)
+	case healthUnhealthy:
+		return ui.ErrorStyle.Render(health)
+	}
+	return ui.MutedStyle.Render(health)
+}
+
+// formatBuildStatus colours a build status by its outcome
+func formatBuildStatus(status string) string {
+	switch buildOutcome(status) {
+	case buildOutcomeSucceeded:
+		return ui.SuccessStyle.Render(status)
+	case buildOutcomeFailed:
+		return ui.ErrorStyle.Render(status)
+	}
+	return ui.WarningStyle.Render(status)
+}
+
+func init() {
+	agentsCmd.AddCommand(agentsStatusCmd)
+
+	agentsStatusCmd.Flags().StringP("env", "e", "", "Only show this environment")
+	agentsStatusCmd.Flags().String("since", "1h", "Window for trace and log health (e.g., 1h, 24h)")
+	agentsStatusCmd.Flags().Int("concurrency", defaultLogsConcurrency, "Number of environments queried at once")
+}
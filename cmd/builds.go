@@ -161,6 +161,9 @@ var buildsGetCmd = &cobra.Command{
 		printBuildRow("Commit:", build.CommitID)
 		printBuildRow("Branch:", valueOrDefault(build.Branch, "(unknown)"))
 		printBuildRow("Status:", ui.StatusCell(build.Status))
+		if build.ImageID != "" {
+			printBuildRow("Image:", build.ImageID)
+		}
 		printBuildRow("Started:", build.StartedAt.Format("2006-01-02 15:04:05"))
 		if build.EndedAt != nil {
 			printBuildRow("Ended:", build.EndedAt.Format("2006-01-02 15:04:05"))
@@ -178,10 +181,16 @@ var buildsGetCmd = &cobra.Command{
 		if len(build.Steps) > 0 {
 			fmt.Println()
 			fmt.Println(ui.SubtitleStyle.Render("  Build Steps:"))
-			for _, step := range build.Steps {
-				stepStatus := ui.StatusCell(step.Status)
-				fmt.Printf("    %s %s - %s\n", stepStatus, step.Type, step.Message)
+			for _, st := range newBuildTimeline(build).Steps {
+				stepStatus := ui.StatusCell(st.Status)
+				duration := ""
+				if st.started() {
+					duration = ui.MutedStyle.Render(" (" + ui.FormatStepDuration(st.duration()) + ")")
+				}
+				fmt.Printf("    %s %s - %s%s\n", stepStatus, st.Type, st.Message, duration)
 			}
+			fmt.Println()
+			fmt.Printf("  • View timeline: amp builds timeline %s --agent %s\n", build.Name, agent)
 		}
 
 		fmt.Println()
@@ -192,6 +201,26 @@ var buildsGetCmd = &cobra.Command{
 var buildsTriggerCmd = &cobra.Command{
 	Use:   "trigger",
 	Short: "Trigger a new build for an agent",
+	Long: `Trigger a new build for an agent.
+
+With --wait the command follows the build until it finishes, showing its steps,
+progress and new log lines. It exits non-zero if the build fails and prints
+the built image ID on stdout, so it can feed straight into deploy. --follow
+does the same but prints plain log lines instead of the live view, which suits
+CI logs; output that is not a terminal is always plain.
+
+--commit accepts HEAD, branch and tag names and short SHAs, resolved against
+the git repository in the current directory without contacting the remote.
+The command refuses to build from a working tree with uncommitted changes
+unless --force is given, and warns when the commit is not yet on the remote
+branch the agent builds from.
+
+Examples:
+  amp builds trigger --agent myagent
+  amp builds trigger --agent myagent --commit HEAD
+  amp builds trigger --agent myagent --wait
+  amp deploy --agent myagent --image "$(amp builds trigger --agent myagent --wait)"
+  amp builds trigger --agent myagent --follow --timeout 20m`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Get flags
 		org, _ := cmd.Flags().GetString("org")
@@ -199,6 +228,10 @@ var buildsTriggerCmd = &cobra.Command{
 		agent, _ := cmd.Flags().GetString("agent")
 		commit, _ := cmd.Flags().GetString("commit")
 		output, _ := cmd.Flags().GetString("output")
+		wait, _ := cmd.Flags().GetBool("wait")
+		follow, _ := cmd.Flags().GetBool("follow")
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		force, _ := cmd.Flags().GetBool("force")
 
 		// Use defaults from config if not provided
 		if org == "" {
@@ -226,12 +259,25 @@ var buildsTriggerCmd = &cobra.Command{
 			config.GetAPIKeyValue(),
 		)
 
+		// Resolve HEAD, branches and short SHAs against the local checkout
+		if commit != "" {
+			resolved, err := resolveBuildCommit(client, org, project, agent, commit, force)
+			if err != nil {
+				return err
+			}
+			commit = resolved
+		}
+
 		// Trigger build
 		build, err := client.TriggerBuild(org, project, agent, commit)
 		if err != nil {
 			return fmt.Errorf("failed to trigger build: %w", err)
 		}
 
+		if wait || follow {
+			return waitForTriggeredBuild(cmd, client, org, project, agent, build, timeout, follow, output)
+		}
+
 		// JSON output
 		if output == "json" {
 			encoder := json.NewEncoder(os.Stdout)
@@ -269,6 +315,17 @@ var buildsLogsCmd = &cobra.Command{
 		project, _ := cmd.Flags().GetString("project")
 		agent, _ := cmd.Flags().GetString("agent")
 		output, _ := cmd.Flags().GetString("output")
+		tui, _ := cmd.Flags().GetBool("tui")
+
+		opts, err := readLogOptions(cmd)
+		if err != nil {
+			return err
+		}
+		if tui {
+			if err := validateTUIFlags(opts, output); err != nil {
+				return err
+			}
+		}
 
 		// Use defaults from config if not provided
 		if org == "" {
@@ -302,6 +359,19 @@ var buildsLogsCmd = &cobra.Command{
 			return fmt.Errorf("failed to get build logs: %w", err)
 		}
 
+		// Apply local --grep/--exclude filters
+		logs.Logs = opts.filterEntries(logs.Logs)
+
+		if tui {
+			title := fmt.Sprintf("%s Logs for Build: %s", ui.IconBuild, buildName)
+			return runLogViewer(title, toLogLines(tagLogEntries("", logs.Logs)), nil, 0)
+		}
+
+		// Plain formats and file output
+		if opts.usesWriter() {
+			return writeLogs(opts, output, false, tagLogEntries(agent, logs.Logs))
+		}
+
 		// JSON output
 		if output == "json" {
 			encoder := json.NewEncoder(os.Stdout)
@@ -355,8 +425,76 @@ var buildsLogsCmd = &cobra.Command{
 	},
 }
 
+// waitForTriggeredBuild follows a new build to the end. Progress goes to stderr
+// and only the image ID (or the build as JSON) goes to stdout.
+func waitForTriggeredBuild(cmd *cobra.Command, client *api.Client, org, project, agent string, build *api.BuildResponse, timeout time.Duration, plain bool, output string) error {
+	fmt.Fprintln(os.Stderr, ui.RenderSuccess(fmt.Sprintf("Build %s triggered for commit %s", build.Name, valueOrDefault(truncateCommit(build.CommitID), "(latest)"))))
+
+	watcher := newBuildWatcher(client, org, project, agent, build.Name)
+	final, waitErr := waitForBuild(watcher, timeout, plain)
+	if final == nil {
+		if waitErr == nil {
+			waitErr = fmt.Errorf("no status received for build %s", build.Name)
+		}
+		return waitErr
+	}
+
+	if output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(final); err != nil {
+			return err
+		}
+	}
+
+	// Failures past this point are about the build, not the command line
+	cmd.SilenceUsage = true
+	if waitErr == errBuildWaitStopped {
+		fmt.Fprintln(os.Stderr, ui.RenderInfo(fmt.Sprintf("Stopped waiting; the build keeps running. Check it with: amp builds get %s --agent %s", build.Name, agent)))
+		return fmt.Errorf("build %s is still %s", build.Name, final.Status)
+	}
+	if waitErr != nil {
+		return waitErr
+	}
+
+	fmt.Fprintln(os.Stderr)
+	if buildOutcome(final.Status) == buildOutcomeFailed {
+		fmt.Fprintf(os.Stderr, "  Logs: amp builds logs %s --agent %s\n\n", build.Name, agent)
+		return fmt.Errorf("build %s %s after %s", build.Name, strings.ToLower(final.Status), formatDuration(final.StartedAt, final.EndedAt))
+	}
+
+	fmt.Fprintln(os.Stderr, ui.RenderSuccess(fmt.Sprintf("Build %s succeeded in %s", build.Name, formatDuration(final.StartedAt, final.EndedAt))))
+	if final.ImageID == "" {
+		fmt.Fprintln(os.Stderr, ui.RenderWarning("The API did not report an image ID for this build"))
+		return nil
+	}
+	if output != "json" {
+		fmt.Println(final.ImageID)
+	}
+	return nil
+}
+
 // Helper functions
 
+// Build outcomes derived from the build status reported by the API
+const (
+	buildOutcomeSucceeded = "succeeded"
+	buildOutcomeFailed    = "failed"
+	buildOutcomeRunning   = "running"
+)
+
+// buildOutcome classifies a build status such as Completed, Failed or BuildRunning
+func buildOutcome(status string) string {
+	s := strings.ToLower(status)
+	switch {
+	case strings.Contains(s, "fail"), strings.Contains(s, "error"), strings.Contains(s, "cancel"):
+		return buildOutcomeFailed
+	case strings.HasSuffix(s, "completed"), strings.HasSuffix(s, "succeeded"), s == "success":
+		return buildOutcomeSucceeded
+	}
+	return buildOutcomeRunning
+}
+
 // printBuildRow prints a styled key-value row for build details
 func printBuildRow(key, value string) {
 	fmt.Printf("  %s  %s\n", ui.KeyStyle.Render(key), ui.ValueStyle.Render(value))
@@ -412,7 +550,13 @@ func init() {
 	buildsGetCmd.Flags().StringP("agent", "a", "", "Agent name (required)")
 	buildsTriggerCmd.Flags().StringP("agent", "a", "", "Agent name (required)")
 	buildsLogsCmd.Flags().StringP("agent", "a", "", "Agent name (required)")
+	buildsLogsCmd.Flags().Bool("tui", false, "Open logs in a full-screen interactive viewer")
+	addLogOutputFlags(buildsLogsCmd)
 
 	// Add --commit flag to trigger command (optional)
-	buildsTriggerCmd.Flags().StringP("commit", "c", "", "Commit ID (defaults to latest)")
+	buildsTriggerCmd.Flags().StringP("commit", "c", "", "Commit to build: a SHA, short SHA, branch, tag or HEAD (defaults to latest)")
+	buildsTriggerCmd.Flags().Bool("force", false, "Trigger even if the working tree has uncommitted changes")
+	buildsTriggerCmd.Flags().Bool("wait", false, "Wait for the build with live progress and print the image ID")
+	buildsTriggerCmd.Flags().Bool("follow", false, "Like --wait, but print plain log lines instead of the live view")
+	buildsTriggerCmd.Flags().Duration("timeout", defaultBuildWaitTimeout, "How long --wait or --follow waits for the build")
 }
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Kavirubc/wso2-amp-cli/internal/api"
+	"github.com/Kavirubc/wso2-amp-cli/internal/gitrepo"
+	"github.com/Kavirubc/wso2-amp-cli/internal/ui"
+)
+
+// maxPushCheckCommits bounds the history walked to tell whether a commit was pushed
+const maxPushCheckCommits = 20000
+
+// maxDirtyPathsShown is how many changed paths the dirty tree error names
+const maxDirtyPathsShown = 3
+
+// resolveBuildCommit turns the --commit value into a full SHA by reading the
+// git repository in the working directory. HEAD, branch and tag names and
+// short SHAs are accepted. It refuses to build from a dirty working tree
+// unless force is set, and warns when the commit is not on the remote branch
+// the agent builds from.
+func resolveBuildCommit(client *api.Client, org, project, agent, rev string, force bool) (string, error) {
+	repo, err := gitrepo.Open(".")
+	if err != nil {
+		// Outside a checkout, a full commit ID is sent as given
+		if errors.Is(err, gitrepo.ErrNotRepository) && gitrepo.IsHex(rev) && len(rev) == 40 {
+			return rev, nil
+		}
+		return "", fmt.Errorf("cannot resolve commit %q: %w", rev, err)
+	}
+
+	resolved, err := repo.Resolve(rev)
+	if err != nil {
+		// The commit may exist on the remote without having been fetched
+		if gitrepo.IsHex(rev) && len(rev) == 40 {
+			fmt.Fprintln(os.Stderr, ui.RenderWarning(fmt.Sprintf("Commit %s is not in the local repository; sending it as given", truncateCommit(rev))))
+			return rev, nil
+		}
+		return "", fmt.Errorf("cannot resolve commit %q: %w", rev, err)
+	}
+	if !strings.EqualFold(rev, resolved.SHA) {
+		fmt.Fprintln(os.Stderr, ui.RenderInfo(fmt.Sprintf("Resolved %s to %s", describeRevision(repo, rev, resolved), truncateCommit(resolved.SHA))))
+	}
+
+	status, err := repo.Status()
+	if err != nil {
+		return "", fmt.Errorf("failed to read working tree status: %w", err)
+	}
+	if !status.Clean() {
+		paths := status.Paths()
+		shown := paths
+		if len(shown) > maxDirtyPathsShown {
+			shown = append(shown[:maxDirtyPathsShown:maxDirtyPathsShown], fmt.Sprintf("and %d more", len(paths)-maxDirtyPathsShown))
+		}
+		if !force {
+			return "", fmt.Errorf("working tree has uncommitted changes (%s). The build uses the committed source only; commit and push them, or use --force", strings.Join(shown, ", "))
+		}
+		fmt.Fprintln(os.Stderr, ui.RenderWarning(fmt.Sprintf("Working tree has uncommitted changes (%s); they are not part of the build", strings.Join(shown, ", "))))
+	}
+
+	warnIfUnpushed(client, repo, org, project, agent, resolved.SHA)
+	return resolved.SHA, nil
+}
+
+// describeRevision names what a revision was resolved through, e.g. "HEAD (main)"
+func describeRevision(repo *gitrepo.Repo, rev string, resolved gitrepo.Revision) string {
+	if resolved.Ref == "HEAD" {
+		if branch := repo.CurrentBranch(); branch != "" {
+			return fmt.Sprintf("%s (%s)", rev, branch)
+		}
+		return rev + " (detached)"
+	}
+	return rev
+}
+
+// warnIfUnpushed warns when the commit is not on the remote branch recorded
+// in the agent's repository settings. It relies on the remote-tracking refs
+// of the last fetch, so it never contacts the remote.
+func warnIfUnpushed(client *api.Client, repo *gitrepo.Repo, org, project, agent, sha string) {
+	warn := func(msg string) {
+		fmt.Fprintln(os.Stderr, ui.RenderWarning(msg))
+	}
+
+	agentInfo, err := client.GetAgent(org, project, agent)
+	if err != nil {
+		warn(fmt.Sprintf("Could not check whether %s was pushed: %v", truncateCommit(sha), err))
+		return
+	}
+	if agentInfo.Provisioning == nil || agentInfo.Provisioning.Repository == nil || agentInfo.Provisioning.Repository.URL == "" {
+		return
+	}
+	source := agentInfo.Provisioning.Repository
+
+	remote, ok := repo.RemoteForURL(source.URL)
+	if !ok {
+		warn(fmt.Sprintf("No remote of this repository points at %s, the agent's source; is this the right checkout?", source.URL))
+		return
+	}
+	branch := valueOrDefault(source.Branch, "main")
+	tracking := remote.Name + "/" + branch
+	tip, err := repo.ReadRef("refs/remotes/" + tracking)
+	if err != nil {
+		warn(fmt.Sprintf("%s has not been fetched, so it is unknown whether %s was pushed", tracking, truncateCommit(sha)))
+		return
+	}
+
+	pushed, known, err := repo.IsAncestor(sha, tip, maxPushCheckCommits)
+	switch {
+	case err != nil:
+		warn(fmt.Sprintf("Could not check whether %s was pushed: %v", truncateCommit(sha), err))
+	case !known:
+		warn(fmt.Sprintf("Could not tell whether %s is on %s", truncateCommit(sha), tracking))
+	case !pushed:
+		warn(fmt.Sprintf("Commit %s is not on %s as of the last fetch; push it to %s before the build checks it out",
+			truncateCommit(sha), tracking, branch))
+	}
+}
@@ -0,0 +1,526 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/Kavirubc/wso2-amp-cli/internal/api"
+	"github.com/Kavirubc/wso2-amp-cli/internal/config"
+	"github.com/Kavirubc/wso2-amp-cli/internal/ui"
+	"github.com/Kavirubc/wso2-amp-cli/internal/util"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// buildStatsPageSize is the page size used to walk every build
+	buildStatsPageSize = 100
+	// maxTrendWidth caps the width of the daily trend sparklines
+	maxTrendWidth = 60
+)
+
+// buildStats summarises a set of builds
+type buildStats struct {
+	Total                int            `json:"total"`
+	Succeeded            int            `json:"succeeded"`
+	Failed               int            `json:"failed"`
+	Running              int            `json:"running"`
+	Finished             int            `json:"finished"`
+	SuccessRate          float64        `json:"successRate"`
+	MeanDurationSeconds  float64        `json:"meanDurationSeconds"`
+	P95DurationSeconds   float64        `json:"p95DurationSeconds"`
+	LongestFailureStreak int            `json:"longestFailureStreak"`
+	CurrentFailureStreak int            `json:"currentFailureStreak"`
+	Branches             []branchStats  `json:"branches"`
+	SlowestCommits       []slowestBuild `json:"slowestCommits"`
+	Daily                []dailyBuilds  `json:"daily"`
+}
+
+// branchStats counts the builds of one branch
+type branchStats struct {
+	Branch      string  `json:"branch"`
+	Builds      int     `json:"builds"`
+	Succeeded   int     `json:"succeeded"`
+	Failed      int     `json:"failed"`
+	SuccessRate float64 `json:"successRate"`
+}
+
+// slowestBuild is the slowest build of a commit
+type slowestBuild struct {
+	CommitID        string  `json:"commitId"`
+	Build           string  `json:"build"`
+	Agent           string  `json:"agent"`
+	Branch          string  `json:"branch,omitempty"`
+	Status          string  `json:"status"`
+	DurationSeconds float64 `json:"durationSeconds"`
+}
+
+// dailyBuilds counts the builds started on one day
+type dailyBuilds struct {
+	Date      string `json:"date"`
+	Builds    int    `json:"builds"`
+	Succeeded int    `json:"succeeded"`
+	Failed    int    `json:"failed"`
+}
+
+// agentBuildStats is the summary of one agent in a project-wide report
+type agentBuildStats struct {
+	Agent string `json:"agent"`
+	buildStats
+}
+
+// buildStatsReport is the JSON shape of builds stats
+type buildStatsReport struct {
+	Project string            `json:"project"`
+	Agent   string            `json:"agent,omitempty"`
+	Since   time.Time         `json:"since"`
+	Until   time.Time         `json:"until"`
+	Overall buildStats        `json:"overall"`
+	Agents  []agentBuildStats `json:"agents,omitempty"`
+	Errors  map[string]string `json:"errors,omitempty"`
+}
+
+// listBuildsSince walks every page of an agent's builds and keeps those
+// started at or after since
+func listBuildsSince(client *api.Client, org, project, agentName string, since time.Time) ([]api.BuildResponse, error) {
+	var builds []api.BuildResponse
+	seen := 0
+	for offset := 0; ; offset += buildStatsPageSize {
+		page, total, err := client.ListBuilds(org, project, agentName, api.ListOptions{Limit: buildStatsPageSize, Offset: offset})
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range page {
+			if !b.StartedAt.Before(since) {
+				builds = append(builds, b)
+			}
+		}
+		seen += len(page)
+		if len(page) == 0 || seen >= total {
+			return builds, nil
+		}
+	}
+}
+
+// buildDurationSeconds returns how long a finished build took
+func buildDurationSeconds(b api.BuildResponse) (float64, bool) {
+	if b.EndedAt == nil || b.StartedAt.IsZero() {
+		return 0, false
+	}
+	return b.EndedAt.Sub(b.StartedAt).Seconds(), true
+}
+
+// successRate is the percentage of finished builds that succeeded
+func successRate(succeeded, failed int) float64 {
+	if succeeded+failed == 0 {
+		return 0
+	}
+	return float64(succeeded) / float64(succeeded+failed) * 100
+}
+
+// computeBuildStats summarises builds over the days from since to until.
+// Failure streaks are counted per agent in start order; the report shows the
+// worst agent.
+func computeBuildStats(builds []api.BuildResponse, since, until time.Time, top int) buildStats {
+	stats := buildStats{
+		Branches:       []branchStats{},
+		SlowestCommits: []slowestBuild{},
+		Daily:          []dailyBuilds{},
+	}
+
+	// One bucket per local calendar day, oldest first
+	dayIndex := make(map[string]int)
+	for d := truncateToDay(since); !d.After(until); d = d.AddDate(0, 0, 1) {
+		key := d.Format("2006-01-02")
+		dayIndex[key] = len(stats.Daily)
+		stats.Daily = append(stats.Daily, dailyBuilds{Date: key})
+	}
+
+	sorted := append([]api.BuildResponse(nil), builds...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].StartedAt.Before(sorted[j].StartedAt) })
+
+	branches := make(map[string]*branchStats)
+	slowest := make(map[string]slowestBuild)
+	streaks := make(map[string]int)
+	var durations []float64
+	for _, b := range sorted {
+		stats.Total++
+		outcome := buildOutcome(b.Status)
+
+		branch := valueOrDefault(b.Branch, "(unknown)")
+		bs, ok := branches[branch]
+		if !ok {
+			bs = &branchStats{Branch: branch}
+			branches[branch] = bs
+		}
+		bs.Builds++
+
+		day := -1
+		if i, ok := dayIndex[b.StartedAt.Local().Format("2006-01-02")]; ok {
+			day = i
+			stats.Daily[i].Builds++
+		}
+
+		switch outcome {
+		case buildOutcomeSucceeded:
+			stats.Succeeded++
+			bs.Succeeded++
+			if day >= 0 {
+				stats.Daily[day].Succeeded++
+			}
+			streaks[b.AgentName] = 0
+		case buildOutcomeFailed:
+			stats.Failed++
+			bs.Failed++
+			if day >= 0 {
+				stats.Daily[day].Failed++
+			}
+			streaks[b.AgentName]++
+			if streaks[b.AgentName] > stats.LongestFailureStreak {
+				stats.LongestFailureStreak = streaks[b.AgentName]
+			}
+		default:
+			stats.Running++
+		}
+
+		if seconds, ok := buildDurationSeconds(b); ok && outcome != buildOutcomeRunning {
+			durations = append(durations, seconds)
+			key := b.AgentName + "\x00" + b.CommitID
+			if prev, ok := slowest[key]; !ok || seconds > prev.DurationSeconds {
+				slowest[key] = slowestBuild{
+					CommitID:        b.CommitID,
+					Build:           b.Name,
+					Agent:           b.AgentName,
+					Branch:          b.Branch,
+					Status:          b.Status,
+					DurationSeconds: seconds,
+				}
+			}
+		}
+	}
+	for _, streak := range streaks {
+		if streak > stats.CurrentFailureStreak {
+			stats.CurrentFailureStreak = streak
+		}
+	}
+
+	stats.Finished = stats.Succeeded + stats.Failed
+	stats.SuccessRate = successRate(stats.Succeeded, stats.Failed)
+	if len(durations) > 0 {
+		sort.Float64s(durations)
+		sum := 0.0
+		for _, d := range durations {
+			sum += d
+		}
+		stats.MeanDurationSeconds = sum / float64(len(durations))
+		stats.P95DurationSeconds = percentile(durations, 95)
+	}
+
+	for _, bs := range branches {
+		bs.SuccessRate = successRate(bs.Succeeded, bs.Failed)
+		stats.Branches = append(stats.Branches, *bs)
+	}
+	sort.Slice(stats.Branches, func(i, j int) bool {
+		if stats.Branches[i].Builds != stats.Branches[j].Builds {
+			return stats.Branches[i].Builds > stats.Branches[j].Builds
+		}
+		return stats.Branches[i].Branch < stats.Branches[j].Branch
+	})
+
+	for _, sb := range slowest {
+		stats.SlowestCommits = append(stats.SlowestCommits, sb)
+	}
+	sort.Slice(stats.SlowestCommits, func(i, j int) bool {
+		return stats.SlowestCommits[i].DurationSeconds > stats.SlowestCommits[j].DurationSeconds
+	})
+	if len(stats.SlowestCommits) > top {
+		stats.SlowestCommits = stats.SlowestCommits[:top]
+	}
+	return stats
+}
+
+// truncateToDay returns local midnight of the day t falls on
+func truncateToDay(t time.Time) time.Time {
+	t = t.Local()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// formatSuccessRate formats a success rate, coloured by how healthy it is
+func formatSuccessRate(rate float64, finished int) string {
+	if finished == 0 {
+		return ui.MutedStyle.Render("-")
+	}
+	text := fmt.Sprintf("%.0f%%", rate)
+	switch {
+	case rate >= 90:
+		return ui.SuccessStyle.Render(text)
+	case rate >= 70:
+		return ui.WarningStyle.Render(text)
+	}
+	return ui.ErrorStyle.Render(text)
+}
+
+// formatStatsDuration formats a duration in seconds, or - when there is none
+func formatStatsDuration(seconds float64) string {
+	if seconds <= 0 {
+		return "-"
+	}
+	return ui.FormatStepDuration(time.Duration(seconds * float64(time.Second)))
+}
+
+// printBuildStats prints the summary, trend, branches and slowest commits
+func printBuildStats(report buildStatsReport) {
+	scope := report.Agent
+	if scope == "" {
+		scope = "project " + report.Project
+	}
+	fmt.Println(ui.TitleStyle.Render(fmt.Sprintf("%s Build Stats: %s", ui.IconBuild, scope)))
+	fmt.Println()
+	printBuildRow("Period:", fmt.Sprintf("%s - %s", report.Since.Local().Format("2006-01-02"), report.Until.Local().Format("2006-01-02")))
+
+	s := report.Overall
+	if s.Total == 0 {
+		fmt.Println()
+		fmt.Println(ui.RenderWarning("No builds in this period."))
+		printBuildStatsErrors(report.Errors)
+		return
+	}
+	printBuildRow("Builds:", fmt.Sprintf("%d (%d succeeded, %d failed, %d running)", s.Total, s.Succeeded, s.Failed, s.Running))
+	printBuildRow("Success Rate:", formatSuccessRate(s.SuccessRate, s.Finished))
+	printBuildRow("Duration:", fmt.Sprintf("mean %s, p95 %s", formatStatsDuration(s.MeanDurationSeconds), formatStatsDuration(s.P95DurationSeconds)))
+	streak := fmt.Sprintf("longest %d, current %d", s.LongestFailureStreak, s.CurrentFailureStreak)
+	if s.CurrentFailureStreak > 0 {
+		streak = ui.ErrorStyle.Render(streak)
+	}
+	printBuildRow("Failure Streak:", streak)
+	fmt.Println()
+
+	// Daily trend
+	builds := make([]float64, len(s.Daily))
+	failures := make([]float64, len(s.Daily))
+	for i, d := range s.Daily {
+		builds[i] = float64(d.Builds)
+		failures[i] = float64(d.Failed)
+	}
+	width := len(s.Daily)
+	if width > maxTrendWidth {
+		width = maxTrendWidth
+	}
+	fmt.Println(ui.SectionStyle.Render("  Daily Trend:"))
+	fmt.Printf("  %s  %s\n", ui.KeyStyle.Render("Builds/day:"), formatTrend(builds, width, ui.SuccessStyle.Render))
+	fmt.Printf("  %s  %s\n", ui.KeyStyle.Render("Failures/day:"), formatTrend(failures, width, ui.ErrorStyle.Render))
+	fmt.Println()
+
+	// Per-agent breakdown for project-wide stats
+	if len(report.Agents) > 0 {
+		headers := []string{"AGENT", "BUILDS", "SUCCESS", "MEAN", "P95", "STREAK"}
+		var rows [][]string
+		for _, a := range report.Agents {
+			rows = append(rows, []string{
+				a.Agent,
+				fmt.Sprintf("%d", a.Total),
+				formatSuccessRate(a.SuccessRate, a.Finished),
+				formatStatsDuration(a.MeanDurationSeconds),
+				formatStatsDuration(a.P95DurationSeconds),
+				fmt.Sprintf("%d", a.CurrentFailureStreak),
+			})
+		}
+		fmt.Println(ui.RenderTableWithTitle("Agents", headers, rows))
+		fmt.Println()
+	}
+
+	// Branches
+	headers := []string{"BRANCH", "BUILDS", "SUCCEEDED", "FAILED", "SUCCESS"}
+	var rows [][]string
+	for _, b := range s.Branches {
+		rows = append(rows, []string{
+			b.Branch,
+			fmt.Sprintf("%d", b.Builds),
+			fmt.Sprintf("%d", b.Succeeded),
+			fmt.Sprintf("%d", b.Failed),
+			formatSuccessRate(b.SuccessRate, b.Succeeded+b.Failed),
+		})
+	}
+	fmt.Println(ui.RenderTableWithTitle("Branches", headers, rows))
+	fmt.Println()
+
+	// Slowest commits
+	if len(s.SlowestCommits) > 0 {
+		headers = []string{"COMMIT", "BUILD", "AGENT", "BRANCH", "STATUS", "DURATION"}
+		rows = nil
+		for _, sb := range s.SlowestCommits {
+			rows = append(rows, []string{
+				truncateCommit(sb.CommitID),
+				sb.Build,
+				sb.Agent,
+				valueOrDefault(sb.Branch, "-"),
+				formatBuildStatus(sb.Status),
+				formatStatsDuration(sb.DurationSeconds),
+			})
+		}
+		fmt.Println(ui.RenderTableWithTitle("Slowest Commits", headers, rows))
+		fmt.Println()
+	}
+
+	printBuildStatsErrors(report.Errors)
+}
+
+// formatTrend renders daily counts as a sparkline with the peak day, or none
+// when every day is zero (a flat sparkline would draw full blocks)
+func formatTrend(values []float64, width int, style func(...string) string) string {
+	peak := 0.0
+	for _, v := range values {
+		if v > peak {
+			peak = v
+		}
+	}
+	if peak == 0 {
+		return ui.MutedStyle.Render("none")
+	}
+	return style(ui.Sparkline(values, width)) + ui.MutedStyle.Render(fmt.Sprintf("  peak %.0f", peak))
+}
+
+// printBuildStatsErrors lists agents whose builds could not be fetched
+func printBuildStatsErrors(errs map[string]string) {
+	if len(errs) == 0 {
+		return
+	}
+	names := make([]string, 0, len(errs))
+	for name := range errs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Println(ui.RenderWarning(fmt.Sprintf("%s: %s", name, errs[name])))
+	}
+	fmt.Println()
+}
+
+var buildsStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show build health over time",
+	Long: `Summarise the builds of an agent, or of every agent in the project when
+--agent is omitted: success rate, mean and p95 duration, failure streaks,
+builds per branch, the slowest commits and a daily trend.
+
+All pages of builds are read, and builds started before --since are left out.
+
+Examples:
+  amp builds stats --agent myagent
+  amp builds stats --agent myagent --since 7d
+  amp builds stats --since 30d --output json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flags
+		org, _ := cmd.Flags().GetString("org")
+		project, _ := cmd.Flags().GetString("project")
+		agentName, _ := cmd.Flags().GetString("agent")
+		since, _ := cmd.Flags().GetString("since")
+		top, _ := cmd.Flags().GetInt("top")
+		concurrency, _ := cmd.Flags().GetInt("concurrency")
+		output, _ := cmd.Flags().GetString("output")
+
+		// Use defaults from config if not provided
+		if org == "" {
+			org = config.GetDefaultOrg()
+		}
+		if project == "" {
+			project = config.GetDefaultProject()
+		}
+
+		// Validate required fields
+		if org == "" {
+			return fmt.Errorf("organization is required. Use --org flag or set default with: amp config set default_org <name>")
+		}
+		if project == "" {
+			return fmt.Errorf("project is required. Use --project flag or set default with: amp config set default_project <name>")
+		}
+		if top < 1 {
+			return fmt.Errorf("--top must be at least 1")
+		}
+
+		start, err := util.ParseSinceDuration(since)
+		if err != nil {
+			return fmt.Errorf("invalid --since value: %w", err)
+		}
+		end := time.Now()
+
+		// Create API client
+		client := api.NewClient(
+			config.GetAPIURL(),
+			config.GetAPIKeyHeader(),
+			config.GetAPIKeyValue(),
+		)
+
+		agents := []string{agentName}
+		if agentName == "" {
+			agents, err = listAllAgentNames(client, org, project)
+			if err != nil {
+				return fmt.Errorf("failed to list agents: %w", err)
+			}
+		}
+
+		// Fetch every agent's builds
+		perAgent := make([][]api.BuildResponse, len(agents))
+		fetchErrs := make([]error, len(agents))
+		runBounded(len(agents), concurrency, func(i int) {
+			perAgent[i], fetchErrs[i] = listBuildsSince(client, org, project, agents[i], start)
+		})
+
+		// A single agent that cannot be read fails the command
+		if agentName != "" && fetchErrs[0] != nil {
+			return fmt.Errorf("failed to list builds: %w", fetchErrs[0])
+		}
+
+		report := buildStatsReport{
+			Project: project,
+			Agent:   agentName,
+			Since:   start,
+			Until:   end,
+		}
+		var all []api.BuildResponse
+		for i, builds := range perAgent {
+			if fetchErrs[i] != nil {
+				if report.Errors == nil {
+					report.Errors = make(map[string]string)
+				}
+				report.Errors[agents[i]] = fetchErrs[i].Error()
+				continue
+			}
+			// Builds are attributed to the agent they were listed for
+			for j := range builds {
+				if builds[j].AgentName == "" {
+					builds[j].AgentName = agents[i]
+				}
+			}
+			all = append(all, builds...)
+			if agentName == "" {
+				report.Agents = append(report.Agents, agentBuildStats{
+					Agent:      agents[i],
+					buildStats: computeBuildStats(builds, start, end, top),
+				})
+			}
+		}
+		report.Overall = computeBuildStats(all, start, end, top)
+
+		// JSON output
+		if output == "json" {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(report)
+		}
+
+		printBuildStats(report)
+		return nil
+	},
+}
+
+func init() {
+	buildsCmd.AddCommand(buildsStatsCmd)
+
+	buildsStatsCmd.Flags().StringP("agent", "a", "", "Agent name (default: every agent in the project)")
+	buildsStatsCmd.Flags().String("since", "30d", "Only include builds started within this window (e.g., 7d, 30d)")
+	buildsStatsCmd.Flags().Int("top", 5, "Number of slowest commits to list")
+	buildsStatsCmd.Flags().Int("concurrency", defaultLogsConcurrency, "Number of agents queried at once")
+}
@@ -0,0 +1,345 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Kavirubc/wso2-amp-cli/internal/api"
+	"github.com/Kavirubc/wso2-amp-cli/internal/config"
+	"github.com/Kavirubc/wso2-amp-cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// Export formats accepted by --format on builds timeline
+const (
+	timelineFormatMermaid = "mermaid"
+	timelineFormatChrome  = "chrome"
+)
+
+// stepTiming is a build step with parsed start and end times
+type stepTiming struct {
+	Type            string  `json:"type"`
+	Status          string  `json:"status"`
+	Message         string  `json:"message,omitempty"`
+	StartedAt       string  `json:"startedAt,omitempty"`
+	FinishedAt      string  `json:"finishedAt,omitempty"`
+	OffsetSeconds   float64 `json:"offsetSeconds"`
+	DurationSeconds float64 `json:"durationSeconds"`
+	Running         bool    `json:"running,omitempty"`
+	Slowest         bool    `json:"slowest,omitempty"`
+
+	start time.Time
+	end   time.Time
+}
+
+// buildTimeline is the timing of every step of a build
+type buildTimeline struct {
+	Build           string       `json:"build"`
+	Agent           string       `json:"agent"`
+	Status          string       `json:"status"`
+	StartedAt       time.Time    `json:"startedAt"`
+	EndedAt         time.Time    `json:"endedAt"`
+	DurationSeconds float64      `json:"durationSeconds"`
+	Steps           []stepTiming `json:"steps"`
+}
+
+// started reports whether the step has a start time
+func (s stepTiming) started() bool {
+	return !s.start.IsZero()
+}
+
+// duration is how long the step ran, up to now for a running step
+func (s stepTiming) duration() time.Duration {
+	if !s.started() {
+		return 0
+	}
+	return s.end.Sub(s.start)
+}
+
+// newBuildTimeline parses the step timestamps of a build. A step that has
+// started but not finished runs until the build ended, or until now.
+func newBuildTimeline(build *api.BuildDetailsResponse) buildTimeline {
+	now := time.Now()
+	buildEnd := now
+	if build.EndedAt != nil {
+		buildEnd = *build.EndedAt
+	}
+
+	tl := buildTimeline{
+		Build:     build.Name,
+		Agent:     build.AgentName,
+		Status:    build.Status,
+		StartedAt: build.StartedAt,
+		EndedAt:   buildEnd,
+	}
+	for _, step := range build.Steps {
+		st := stepTiming{
+			Type:       step.Type,
+			Status:     step.Status,
+			Message:    step.Message,
+			StartedAt:  step.StartedAt,
+			FinishedAt: step.FinishedAt,
+			start:      parseLogTime(step.StartedAt, time.Time{}),
+			end:        parseLogTime(step.FinishedAt, time.Time{}),
+		}
+		if st.started() && st.end.IsZero() {
+			st.end = buildEnd
+			st.Running = buildOutcome(step.Status) == buildOutcomeRunning
+		}
+		tl.Steps = append(tl.Steps, st)
+	}
+
+	// The axis covers the build and every step, whichever reaches further
+	for _, st := range tl.Steps {
+		if !st.started() {
+			continue
+		}
+		if tl.StartedAt.IsZero() || st.start.Before(tl.StartedAt) {
+			tl.StartedAt = st.start
+		}
+		if st.end.After(tl.EndedAt) {
+			tl.EndedAt = st.end
+		}
+	}
+	tl.DurationSeconds = tl.EndedAt.Sub(tl.StartedAt).Seconds()
+
+	slowest := -1
+	for i, st := range tl.Steps {
+		if !st.started() {
+			continue
+		}
+		tl.Steps[i].OffsetSeconds = st.start.Sub(tl.StartedAt).Seconds()
+		tl.Steps[i].DurationSeconds = st.duration().Seconds()
+		if slowest < 0 || st.duration() > tl.Steps[slowest].duration() {
+			slowest = i
+		}
+	}
+	// A single step is trivially the slowest, so only mark one among several
+	if slowest >= 0 && len(tl.Steps) > 1 && tl.Steps[slowest].duration() > 0 {
+		tl.Steps[slowest].Slowest = true
+	}
+	return tl
+}
+
+// timelineState maps a step to the state used to colour its bar
+func timelineState(st stepTiming) string {
+	switch {
+	case !st.started():
+		return ui.TimelinePending
+	case buildOutcome(st.Status) == buildOutcomeFailed:
+		return ui.TimelineFailed
+	case st.Running:
+		return ui.TimelineRunning
+	}
+	return ui.TimelineDone
+}
+
+// printBuildTimeline draws the steps as bars on the build's time axis
+func printBuildTimeline(tl buildTimeline) {
+	fmt.Println(ui.TitleStyle.Render(fmt.Sprintf("%s Build Timeline: %s", ui.IconBuild, tl.Build)))
+	fmt.Println()
+	printBuildRow("Status:", formatBuildStatus(tl.Status))
+	printBuildRow("Started:", tl.StartedAt.Local().Format("2006-01-02 15:04:05"))
+	printBuildRow("Duration:", ui.FormatStepDuration(tl.EndedAt.Sub(tl.StartedAt)))
+	fmt.Println()
+
+	if len(tl.Steps) == 0 {
+		fmt.Println(ui.RenderWarning("The build has no steps yet."))
+		return
+	}
+
+	rows := make([]ui.TimelineRow, len(tl.Steps))
+	for i, st := range tl.Steps {
+		rows[i] = ui.TimelineRow{
+			Label:   st.Type,
+			State:   timelineState(st),
+			Start:   st.start,
+			End:     st.end,
+			Slowest: st.Slowest,
+		}
+	}
+	fmt.Print(ui.RenderTimeline(rows, tl.StartedAt, tl.EndedAt, terminalWidth()))
+	fmt.Println()
+
+	for _, st := range tl.Steps {
+		if st.Slowest && tl.DurationSeconds > 0 {
+			fmt.Println(ui.RenderInfo(fmt.Sprintf("%s took %s, %.0f%% of the build",
+				st.Type, ui.FormatStepDuration(st.duration()), st.DurationSeconds/tl.DurationSeconds*100)))
+			fmt.Println()
+		}
+	}
+}
+
+// writeMermaidGantt writes the timeline as a Mermaid gantt chart
+func writeMermaidGantt(w io.Writer, tl buildTimeline) error {
+	var b strings.Builder
+	b.WriteString("gantt\n")
+	fmt.Fprintf(&b, "    title Build %s (%s)\n", tl.Build, tl.Status)
+	b.WriteString("    dateFormat x\n")
+	b.WriteString("    axisFormat %H:%M:%S\n")
+	fmt.Fprintf(&b, "    section %s\n", valueOrDefault(tl.Agent, "steps"))
+	for i, st := range tl.Steps {
+		if !st.started() {
+			continue
+		}
+		var tags []string
+		switch timelineState(st) {
+		case ui.TimelineFailed:
+			tags = append(tags, "crit")
+		case ui.TimelineRunning:
+			tags = append(tags, "active")
+		default:
+			tags = append(tags, "done")
+		}
+		if st.Slowest && tags[0] != "crit" {
+			tags = append([]string{"crit"}, tags...)
+		}
+		tags = append(tags, fmt.Sprintf("step%d", i))
+		// Mermaid ends a task name at a colon
+		name := strings.ReplaceAll(st.Type, ":", " ")
+		fmt.Fprintf(&b, "    %s :%s, %d, %d\n", name, strings.Join(tags, ", "), st.start.UnixMilli(), st.end.UnixMilli())
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// traceEvent is an event of the Chrome trace event format
+type traceEvent struct {
+	Name string                 `json:"name"`
+	Cat  string                 `json:"cat,omitempty"`
+	Ph   string                 `json:"ph"`
+	Ts   int64                  `json:"ts"`
+	Dur  int64                  `json:"dur"`
+	Pid  int                    `json:"pid"`
+	Tid  int                    `json:"tid"`
+	Args map[string]interface{} `json:"args,omitempty"`
+}
+
+// writeChromeTrace writes the timeline in the Chrome trace event format, which
+// chrome://tracing and Perfetto open. Times are microseconds from the build start.
+func writeChromeTrace(w io.Writer, tl buildTimeline) error {
+	events := []traceEvent{
+		{Name: "process_name", Ph: "M", Pid: 1, Tid: 1, Args: map[string]interface{}{"name": "build " + tl.Build}},
+	}
+	for _, st := range tl.Steps {
+		if !st.started() {
+			continue
+		}
+		args := map[string]interface{}{"status": st.Status}
+		if st.Message != "" {
+			args["message"] = st.Message
+		}
+		if st.Slowest {
+			args["slowest"] = true
+		}
+		events = append(events, traceEvent{
+			Name: st.Type,
+			Cat:  "build",
+			Ph:   "X",
+			Ts:   st.start.Sub(tl.StartedAt).Microseconds(),
+			Dur:  st.duration().Microseconds(),
+			Pid:  1,
+			Tid:  1,
+			Args: args,
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(map[string]interface{}{
+		"traceEvents":     events,
+		"displayTimeUnit": "ms",
+	})
+}
+
+var buildsTimelineCmd = &cobra.Command{
+	Use:   "timeline <build-name>",
+	Short: "Show build steps on a timeline",
+	Long: `Show how long each step of a build took, drawn as bars on a shared time
+axis. The slowest step is highlighted.
+
+Use --format to export the timeline as a Mermaid gantt chart or as Chrome
+trace events, which chrome://tracing and https://ui.perfetto.dev can open.
+
+Examples:
+  amp builds timeline my-build-1 --agent myagent
+  amp builds timeline my-build-1 --agent myagent --format mermaid
+  amp builds timeline my-build-1 --agent myagent --format chrome > build.trace.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		buildName := args[0]
+
+		// Get flags
+		org, _ := cmd.Flags().GetString("org")
+		project, _ := cmd.Flags().GetString("project")
+		agent, _ := cmd.Flags().GetString("agent")
+		output, _ := cmd.Flags().GetString("output")
+		format, _ := cmd.Flags().GetString("format")
+
+		switch format {
+		case "", timelineFormatMermaid, timelineFormatChrome:
+		default:
+			return fmt.Errorf("invalid --format %q: must be %s or %s", format, timelineFormatMermaid, timelineFormatChrome)
+		}
+		if format != "" && output == "json" {
+			return fmt.Errorf("--format cannot be combined with --output json")
+		}
+
+		// Use defaults from config if not provided
+		if org == "" {
+			org = config.GetDefaultOrg()
+		}
+		if project == "" {
+			project = config.GetDefaultProject()
+		}
+
+		// Validate required fields
+		if org == "" {
+			return fmt.Errorf("organization is required. Use --org flag or set default with: amp config set default_org <name>")
+		}
+		if project == "" {
+			return fmt.Errorf("project is required. Use --project flag or set default with: amp config set default_project <name>")
+		}
+		if agent == "" {
+			return fmt.Errorf("agent name is required. Use --agent flag")
+		}
+
+		// Create API client
+		client := api.NewClient(
+			config.GetAPIURL(),
+			config.GetAPIKeyHeader(),
+			config.GetAPIKeyValue(),
+		)
+
+		build, err := client.GetBuild(org, project, agent, buildName)
+		if err != nil {
+			return fmt.Errorf("failed to get build: %w", err)
+		}
+		tl := newBuildTimeline(build)
+
+		switch {
+		case format == timelineFormatMermaid:
+			return writeMermaidGantt(os.Stdout, tl)
+		case format == timelineFormatChrome:
+			return writeChromeTrace(os.Stdout, tl)
+		case output == "json":
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(tl)
+		}
+
+		printBuildTimeline(tl)
+		return nil
+	},
+}
+
+func init() {
+	buildsCmd.AddCommand(buildsTimelineCmd)
+
+	buildsTimelineCmd.Flags().StringP("agent", "a", "", "Agent name (required)")
+	buildsTimelineCmd.Flags().String("format", "", "Export format: mermaid or chrome (trace events)")
+}
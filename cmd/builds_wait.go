@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Kavirubc/wso2-amp-cli/internal/api"
+	"github.com/Kavirubc/wso2-amp-cli/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/term"
+)
+
+const (
+	// buildPollInterval is how often a build is polled while waiting
+	buildPollInterval = 3 * time.Second
+	// defaultBuildWaitTimeout bounds how long --wait waits for a build
+	defaultBuildWaitTimeout = 30 * time.Minute
+)
+
+// buildWatcher polls a build and the log lines it has not returned yet.
+// Build logs come back in full on every call, so seen lines are remembered.
+type buildWatcher struct {
+	client    *api.Client
+	org       string
+	project   string
+	agentName string
+	buildName string
+	seen      map[string]bool
+}
+
+// newBuildWatcher creates a watcher for one build
+func newBuildWatcher(client *api.Client, org, project, agentName, buildName string) *buildWatcher {
+	return &buildWatcher{
+		client:    client,
+		org:       org,
+		project:   project,
+		agentName: agentName,
+		buildName: buildName,
+		seen:      make(map[string]bool),
+	}
+}
+
+// poll fetches the build and its new log lines. Logs are best effort: they may
+// not exist yet early in a build, so log errors are not reported.
+func (w *buildWatcher) poll() (*api.BuildDetailsResponse, []api.LogEntry, error) {
+	build, err := w.client.GetBuild(w.org, w.project, w.agentName, w.buildName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get build: %w", err)
+	}
+
+	var fresh []api.LogEntry
+	if logs, err := w.client.GetBuildLogs(w.org, w.project, w.agentName, w.buildName); err == nil {
+		for _, entry := range logs.Logs {
+			key := logEntryKey(entry)
+			if w.seen[key] {
+				continue
+			}
+			w.seen[key] = true
+			fresh = append(fresh, entry)
+		}
+	}
+	return build, fresh, nil
+}
+
+// errBuildWaitStopped is returned when the user stops waiting in the progress view
+var errBuildWaitStopped = fmt.Errorf("stopped waiting")
+
+// waitForBuild polls until the build finishes or the timeout passes. It shows
+// the live progress view when stderr is a terminal and plain is false, and
+// otherwise prints step changes and log lines to stderr as they arrive.
+func waitForBuild(w *buildWatcher, timeout time.Duration, plain bool) (*api.BuildDetailsResponse, error) {
+	if plain || !term.IsTerminal(os.Stderr.Fd()) {
+		return waitForBuildPlain(w, timeout)
+	}
+
+	deadline := time.Now().Add(timeout)
+
+	timedOut := false
+	poll := func() (ui.BuildProgress, error) {
+		build, entries, err := w.poll()
+		progress := ui.BuildProgress{Build: build, Lines: toLogLines(tagLogEntries("", entries))}
+		if build != nil && buildOutcome(build.Status) != buildOutcomeRunning {
+			progress.Done = true
+		} else if time.Now().After(deadline) {
+			timedOut = true
+			progress.Done = true
+		}
+		return progress, err
+	}
+
+	title := fmt.Sprintf("%s Build: %s", ui.IconBuild, w.buildName)
+	model := ui.NewBuildProgressModel(title, poll, buildPollInterval)
+	final, err := tea.NewProgram(model, tea.WithOutput(os.Stderr)).Run()
+	if err != nil {
+		return nil, fmt.Errorf("progress view failed: %w", err)
+	}
+	result := final.(ui.BuildProgressModel)
+	switch {
+	case result.Interrupted():
+		return result.Build(), errBuildWaitStopped
+	case timedOut:
+		return result.Build(), fmt.Errorf("timed out after %s waiting for build %s", timeout, w.buildName)
+	}
+	return result.Build(), nil
+}
+
+// waitForBuildPlain is the non-interactive form of waitForBuild
+func waitForBuildPlain(w *buildWatcher, timeout time.Duration) (*api.BuildDetailsResponse, error) {
+	deadline := time.Now().Add(timeout)
+	stepStatus := make(map[string]string)
+	var build *api.BuildDetailsResponse
+	for {
+		latest, entries, err := w.poll()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, ui.RenderWarning(err.Error()))
+		} else {
+			build = latest
+			for _, step := range build.Steps {
+				if stepStatus[step.Type] == step.Status {
+					continue
+				}
+				stepStatus[step.Type] = step.Status
+				fmt.Fprintf(os.Stderr, "%s %s %s\n", ui.MutedStyle.Render(time.Now().Format("15:04:05")), step.Type, formatBuildStatus(step.Status))
+			}
+			for _, entry := range entries {
+				fmt.Fprintf(os.Stderr, "%s %s %s\n", ui.FormatLogTimestamp(entry.Timestamp), ui.FormatLogLevel(entry.LogLevel), entry.Log)
+			}
+			if buildOutcome(build.Status) != buildOutcomeRunning {
+				return build, nil
+			}
+		}
+		if time.Now().After(deadline) {
+			return build, fmt.Errorf("timed out after %s waiting for build %s", timeout, w.buildName)
+		}
+		time.Sleep(buildPollInterval)
+	}
+}
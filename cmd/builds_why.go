@@ -0,0 +1,407 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Kavirubc/wso2-amp-cli/internal/api"
+	"github.com/Kavirubc/wso2-amp-cli/internal/config"
+	"github.com/Kavirubc/wso2-amp-cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// defaultWhyLines caps the log excerpt of builds why
+	defaultWhyLines = 30
+	// whyWindowSlack widens the failed step's window, since log timestamps can
+	// lag or lead the step timestamps slightly
+	whyWindowSlack = 5 * time.Second
+	// whyContextLines is how many lines around an error the excerpt keeps
+	whyContextLines = 2
+)
+
+// failureSignature recognises a common cause of build failures in log lines
+type failureSignature struct {
+	Name    string
+	Pattern *regexp.Regexp
+	Hint    string
+}
+
+// failureSignatures are checked in order; a line counts for the first match
+var failureSignatures = []failureSignature{
+	{
+		Name:    "missing-dockerfile",
+		Pattern: regexp.MustCompile(`(?i)(dockerfile.*(not found|no such file|does not exist)|failed to read dockerfile|cannot locate specified dockerfile)`),
+		Hint:    "No Dockerfile was found. Check the agent's build path and that the Dockerfile is committed at that commit.",
+	},
+	{
+		Name:    "out-of-memory",
+		Pattern: regexp.MustCompile(`(?i)(out of memory|oomkilled|exit code:? 137|killed signal 9|heap out of memory|memoryerror|cannot allocate memory)`),
+		Hint:    "The build ran out of memory. Reduce build parallelism or the memory the build step needs.",
+	},
+	{
+		Name:    "dependency-resolution",
+		Pattern: regexp.MustCompile(`(?i)(could not resolve|unable to resolve|failed to resolve|no matching distribution|could not find a version|eresolve|npm err! (404|code e404)|cannot find module|modulenotfounderror|unknown revision|version solving failed|package .* not found)`),
+		Hint:    "A dependency could not be resolved. Check the package names and versions, and that the registry is reachable from the build.",
+	},
+	{
+		Name:    "source-checkout",
+		Pattern: regexp.MustCompile(`(?i)(authentication failed|permission denied \(publickey\)|repository not found|could not read from remote|couldn't find remote ref|reference is not a tree)`),
+		Hint:    "The source could not be checked out. Check the repository URL, branch and commit, and the credentials the build uses.",
+	},
+	{
+		Name:    "test-failure",
+		Pattern: regexp.MustCompile(`(?i)(tests? failed|^\s*fail[: \t]|--- fail|failures?: [1-9]|assertionerror|\b[1-9]\d* (tests? )?failed)`),
+		Hint:    "Tests failed during the build. Run them locally at the same commit to reproduce.",
+	},
+}
+
+// matchFailureSignature returns the first signature a log line matches
+func matchFailureSignature(line string) *failureSignature {
+	for i := range failureSignatures {
+		if failureSignatures[i].Pattern.MatchString(line) {
+			return &failureSignatures[i]
+		}
+	}
+	return nil
+}
+
+// failureFinding is a failure signature found in the logs
+type failureFinding struct {
+	Signature string `json:"signature"`
+	Hint      string `json:"hint"`
+	Count     int    `json:"count"`
+	FirstLine string `json:"firstLine"`
+}
+
+// diagnosisLine is a log line of the excerpt
+type diagnosisLine struct {
+	Timestamp string `json:"timestamp"`
+	Level     string `json:"level,omitempty"`
+	Log       string `json:"log"`
+	Error     bool   `json:"error,omitempty"`
+	Signature string `json:"signature,omitempty"`
+}
+
+// buildDiagnosis explains why a build failed
+type buildDiagnosis struct {
+	Build       string           `json:"build"`
+	Agent       string           `json:"agent"`
+	Status      string           `json:"status"`
+	CommitID    string           `json:"commitId,omitempty"`
+	Failed      bool             `json:"failed"`
+	FailedStep  *stepTiming      `json:"failedStep,omitempty"`
+	WindowStart *time.Time       `json:"windowStart,omitempty"`
+	WindowEnd   *time.Time       `json:"windowEnd,omitempty"`
+	LinesInStep int              `json:"linesInStep"`
+	ErrorCount  int              `json:"errorCount"`
+	Findings    []failureFinding `json:"findings"`
+	Excerpt     []diagnosisLine  `json:"excerpt"`
+	Summary     string           `json:"summary"`
+}
+
+// diagnoseBuild finds the failed step of a build and the log lines that explain it
+func diagnoseBuild(build *api.BuildDetailsResponse, logs []api.LogEntry, maxLines int) buildDiagnosis {
+	d := buildDiagnosis{
+		Build:    build.Name,
+		Agent:    build.AgentName,
+		Status:   build.Status,
+		CommitID: build.CommitID,
+		Failed:   buildOutcome(build.Status) == buildOutcomeFailed,
+		Findings: []failureFinding{},
+		Excerpt:  []diagnosisLine{},
+	}
+	if !d.Failed {
+		d.Summary = fmt.Sprintf("Build %s is %s; nothing to diagnose", build.Name, strings.ToLower(build.Status))
+		return d
+	}
+
+	tl := newBuildTimeline(build)
+	for i := range tl.Steps {
+		if buildOutcome(tl.Steps[i].Status) == buildOutcomeFailed {
+			step := tl.Steps[i]
+			d.FailedStep = &step
+			break
+		}
+	}
+
+	// Only keep lines from the failed step's time window. Without usable step
+	// times, or when no line falls in the window, the whole log is used.
+	window := logs
+	if d.FailedStep != nil && d.FailedStep.started() {
+		start := d.FailedStep.start.Add(-whyWindowSlack)
+		end := d.FailedStep.end.Add(whyWindowSlack)
+		d.WindowStart, d.WindowEnd = &start, &end
+		var inStep []api.LogEntry
+		for _, entry := range logs {
+			t := parseLogTime(entry.Timestamp, time.Time{})
+			if !t.IsZero() && !t.Before(start) && !t.After(end) {
+				inStep = append(inStep, entry)
+			}
+		}
+		if len(inStep) > 0 {
+			window = inStep
+		} else {
+			d.WindowStart, d.WindowEnd = nil, nil
+		}
+	}
+	d.LinesInStep = len(window)
+
+	// Classify every line and remember which ones are worth showing
+	lines := make([]diagnosisLine, len(window))
+	notable := make([]bool, len(window))
+	findings := make(map[string]*failureFinding)
+	var order []string
+	for i, entry := range window {
+		line := diagnosisLine{Timestamp: entry.Timestamp, Level: entry.LogLevel, Log: entry.Log}
+		line.Error = strings.EqualFold(entry.LogLevel, "error")
+		if line.Error {
+			d.ErrorCount++
+		}
+		if sig := matchFailureSignature(entry.Log); sig != nil {
+			line.Signature = sig.Name
+			f, ok := findings[sig.Name]
+			if !ok {
+				f = &failureFinding{Signature: sig.Name, Hint: sig.Hint, FirstLine: strings.TrimSpace(entry.Log)}
+				findings[sig.Name] = f
+				order = append(order, sig.Name)
+			}
+			f.Count++
+		}
+		lines[i] = line
+		notable[i] = line.Error || line.Signature != ""
+	}
+	for _, name := range order {
+		d.Findings = append(d.Findings, *findings[name])
+	}
+	d.Excerpt = selectExcerpt(lines, notable, maxLines)
+	d.Summary = summarizeDiagnosis(d)
+	return d
+}
+
+// selectExcerpt keeps notable lines with a little context, or the last lines
+// when nothing stands out, capped at maxLines
+func selectExcerpt(lines []diagnosisLine, notable []bool, maxLines int) []diagnosisLine {
+	keep := make([]bool, len(lines))
+	found := false
+	for i, n := range notable {
+		if !n {
+			continue
+		}
+		found = true
+		for j := i - whyContextLines; j <= i+whyContextLines; j++ {
+			if j >= 0 && j < len(lines) {
+				keep[j] = true
+			}
+		}
+	}
+
+	excerpt := []diagnosisLine{}
+	if !found {
+		start := len(lines) - maxLines
+		if start < 0 {
+			start = 0
+		}
+		return append(excerpt, lines[start:]...)
+	}
+	for i, k := range keep {
+		if k {
+			excerpt = append(excerpt, lines[i])
+		}
+	}
+	// The first failure usually explains the rest, so cut from the end
+	if maxLines > 0 && len(excerpt) > maxLines {
+		excerpt = excerpt[:maxLines]
+	}
+	return excerpt
+}
+
+// summarizeDiagnosis writes a one-line explanation of the failure
+func summarizeDiagnosis(d buildDiagnosis) string {
+	where := "Build " + d.Build + " failed"
+	if d.FailedStep != nil {
+		where = fmt.Sprintf("Build %s failed in step %s", d.Build, d.FailedStep.Type)
+		if d.FailedStep.started() {
+			where += " after " + ui.FormatStepDuration(d.FailedStep.duration())
+		}
+	}
+
+	switch {
+	case len(d.Findings) > 0:
+		f := d.Findings[0]
+		return fmt.Sprintf("%s: likely %s (%s)", where, strings.ReplaceAll(f.Signature, "-", " "), f.FirstLine)
+	case d.ErrorCount > 0:
+		first := ""
+		for _, line := range d.Excerpt {
+			if line.Error {
+				first = strings.TrimSpace(line.Log)
+				break
+			}
+		}
+		return fmt.Sprintf("%s with %d error line(s); first: %s", where, d.ErrorCount, first)
+	case d.FailedStep != nil && d.FailedStep.Message != "":
+		return fmt.Sprintf("%s: %s. No errors found in its logs", where, d.FailedStep.Message)
+	}
+	return where + ". No errors found in its logs"
+}
+
+// printBuildDiagnosis prints the failing step, findings and log excerpt
+func printBuildDiagnosis(d buildDiagnosis) {
+	fmt.Println(ui.TitleStyle.Render(fmt.Sprintf("%s Build Diagnosis: %s", ui.IconBuild, d.Build)))
+	fmt.Println()
+
+	if !d.Failed {
+		fmt.Println(ui.RenderInfo(d.Summary))
+		fmt.Println()
+		return
+	}
+
+	printBuildRow("Status:", formatBuildStatus(d.Status))
+	printBuildRow("Commit:", valueOrDefault(d.CommitID, "(unknown)"))
+	if d.FailedStep != nil {
+		printBuildRow("Failed Step:", ui.ErrorStyle.Render(d.FailedStep.Type))
+		if d.FailedStep.Message != "" {
+			printBuildRow("Message:", d.FailedStep.Message)
+		}
+		if d.FailedStep.started() {
+			printBuildRow("Step Ran:", fmt.Sprintf("%s to %s (%s)",
+				d.FailedStep.start.Local().Format("15:04:05"), d.FailedStep.end.Local().Format("15:04:05"),
+				ui.FormatStepDuration(d.FailedStep.duration())))
+		}
+	} else {
+		printBuildRow("Failed Step:", ui.MutedStyle.Render("(not reported)"))
+	}
+	scope := "whole build log"
+	if d.WindowStart != nil {
+		scope = "lines in the failed step"
+	}
+	printBuildRow("Log Lines:", fmt.Sprintf("%d %s, %d error(s)", d.LinesInStep, scope, d.ErrorCount))
+	fmt.Println()
+
+	if len(d.Findings) > 0 {
+		fmt.Println(ui.SubtitleStyle.Render("  Likely Causes:"))
+		for _, f := range d.Findings {
+			fmt.Printf("    %s %s %s\n", ui.WarningStyle.Render(ui.IconWarning), ui.WarningStyle.Render(f.Signature), ui.MutedStyle.Render(fmt.Sprintf("(%d line(s))", f.Count)))
+			fmt.Printf("      %s\n", f.Hint)
+		}
+		fmt.Println()
+	}
+
+	if len(d.Excerpt) > 0 {
+		fmt.Println(ui.SubtitleStyle.Render("  Log Excerpt:"))
+		for _, line := range d.Excerpt {
+			text := line.Log
+			switch {
+			case line.Signature != "":
+				text = ui.WarningStyle.Render(text) + " " + ui.MutedStyle.Render("["+line.Signature+"]")
+			case line.Error:
+				text = ui.ErrorStyle.Render(text)
+			}
+			prefix := ui.FormatLogTimestamp(line.Timestamp)
+			if level := ui.FormatLogLevel(line.Level); level != "" {
+				prefix += " " + level
+			}
+			fmt.Printf("    %s %s\n", prefix, text)
+		}
+		fmt.Println()
+	}
+
+	fmt.Println(ui.RenderError(d.Summary))
+	fmt.Println()
+	fmt.Printf("  • Full logs: amp builds logs %s --agent %s\n", d.Build, d.Agent)
+	fmt.Println()
+}
+
+var buildsWhyCmd = &cobra.Command{
+	Use:   "why <build-name>",
+	Short: "Explain why a build failed",
+	Long: `Find the step a build failed in and show the log lines from that step.
+
+Error lines are highlighted, and lines that match known failure causes
+(missing Dockerfile, out of memory, dependency resolution, source checkout,
+test failures) are flagged with a hint. A one-line summary closes the report;
+--output json returns the same diagnosis as JSON.
+
+Examples:
+  amp builds why my-build-4 --agent myagent
+  amp builds why my-build-4 --agent myagent --lines 50
+  amp builds why my-build-4 --agent myagent --output json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		buildName := args[0]
+
+		// Get flags
+		org, _ := cmd.Flags().GetString("org")
+		project, _ := cmd.Flags().GetString("project")
+		agent, _ := cmd.Flags().GetString("agent")
+		output, _ := cmd.Flags().GetString("output")
+		maxLines, _ := cmd.Flags().GetInt("lines")
+
+		// Use defaults from config if not provided
+		if org == "" {
+			org = config.GetDefaultOrg()
+		}
+		if project == "" {
+			project = config.GetDefaultProject()
+		}
+
+		// Validate required fields
+		if org == "" {
+			return fmt.Errorf("organization is required. Use --org flag or set default with: amp config set default_org <name>")
+		}
+		if project == "" {
+			return fmt.Errorf("project is required. Use --project flag or set default with: amp config set default_project <name>")
+		}
+		if agent == "" {
+			return fmt.Errorf("agent name is required. Use --agent flag")
+		}
+		if maxLines <= 0 {
+			return fmt.Errorf("--lines must be positive")
+		}
+
+		// Create API client
+		client := api.NewClient(
+			config.GetAPIURL(),
+			config.GetAPIKeyHeader(),
+			config.GetAPIKeyValue(),
+		)
+
+		build, err := client.GetBuild(org, project, agent, buildName)
+		if err != nil {
+			return fmt.Errorf("failed to get build: %w", err)
+		}
+
+		var entries []api.LogEntry
+		if buildOutcome(build.Status) == buildOutcomeFailed {
+			logs, err := client.GetBuildLogs(org, project, agent, buildName)
+			if err != nil {
+				return fmt.Errorf("failed to get build logs: %w", err)
+			}
+			entries = logs.Logs
+			sortLogEntries(entries)
+		}
+
+		diagnosis := diagnoseBuild(build, entries, maxLines)
+
+		// JSON output
+		if output == "json" {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(diagnosis)
+		}
+
+		printBuildDiagnosis(diagnosis)
+		return nil
+	},
+}
+
+func init() {
+	buildsCmd.AddCommand(buildsWhyCmd)
+
+	buildsWhyCmd.Flags().StringP("agent", "a", "", "Agent name (required)")
+	buildsWhyCmd.Flags().Int("lines", defaultWhyLines, "Maximum number of log lines in the excerpt")
+}
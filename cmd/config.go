@@ -2,8 +2,10 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/Kavirubc/wso2-amp-cli/internal/config"
+	"github.com/Kavirubc/wso2-amp-cli/internal/secrets"
 	"github.com/Kavirubc/wso2-amp-cli/internal/ui"
 	"github.com/spf13/cobra"
 )
@@ -19,7 +21,12 @@ Available keys:
   api_key_header  - Auth header name (default: Authorization)
   api_key         - Your JWT token (e.g., Bearer eyJ...)
   default_org     - Default organization name
-  default_project - Default project name`,
+  default_project - Default project name
+
+Secret detection (used when masking env vars and config values):
+  secret_include  - Extra regexes (one per line) for keys to mask
+  secret_exclude  - Regexes (one per line) for keys never to mask
+  secret_entropy  - Entropy in bits/char above which values are masked (0 disables)`,
 }
 
 var configSetCmd = &cobra.Command{
@@ -31,10 +38,29 @@ var configSetCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		key := args[0]
 		value := args[1]
+
+		// Validate secret-detection settings before saving them
+		switch key {
+		case config.KeySecretInclude, config.KeySecretExclude:
+			if err := secrets.ValidatePatterns(config.SplitPatterns(value)); err != nil {
+				return err
+			}
+		case config.KeySecretEntropy:
+			if _, err := config.ParseEntropyThreshold(value); err != nil {
+				return err
+			}
+		}
+
 		if err := config.Set(key, value); err != nil {
 			return fmt.Errorf("failed to save config: %w", err)
 		}
-		fmt.Println(ui.RenderSuccess(fmt.Sprintf("Set %s = %s", key, value)))
+
+		// Avoid echoing secrets back to the terminal
+		display := value
+		if policy, err := loadSecretPolicy(); err == nil && isSensitiveConfig(policy, key, value) {
+			display = secrets.Mask(value)
+		}
+		fmt.Println(ui.RenderSuccess(fmt.Sprintf("Set %s = %s", key, display)))
 		return nil
 	},
 }
@@ -46,6 +72,18 @@ var configGetCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		key := args[0]
 		value := config.Get(key)
+		showSecrets, _ := cmd.Flags().GetBool("show-secrets")
+
+		// Mask sensitive values unless --show-secrets is specified
+		policy, err := loadSecretPolicy()
+		if err != nil {
+			return err
+		}
+		if value != "" && !showSecrets && isSensitiveConfig(policy, key, value) {
+			fmt.Printf("%s  %s\n", ui.KeyStyle.Render(key+":"), ui.MaskedStyle.Render(secrets.Mask(value)))
+			return nil
+		}
+
 		if value == "" {
 			fmt.Printf("%s  %s\n", ui.KeyStyle.Render(key+":"), ui.MutedStyle.Render("(not set)"))
 		} else {
@@ -58,28 +96,66 @@ var configGetCmd = &cobra.Command{
 var configListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all configuration values",
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
+		policy, err := loadSecretPolicy()
+		if err != nil {
+			return err
+		}
+
+		// printPolicyRow masks a value when the secret policy marks it sensitive
+		printPolicyRow := func(key, value string) {
+			if value != "" && isSensitiveConfig(policy, key, value) {
+				printConfigRow(key, secrets.Mask(value), true)
+				return
+			}
+			printConfigRow(key, valueOrDefault(value, "(not set)"), false)
+		}
+
 		fmt.Println(ui.TitleStyle.Render(fmt.Sprintf("%s Current Configuration", ui.IconConfig)))
 		fmt.Println()
 
 		// API Settings
 		fmt.Println(ui.SectionStyle.Render("API Settings"))
-		printConfigRow("api_url", valueOrDefault(config.GetAPIURL(), "(not set)"), false)
-		printConfigRow("api_key_header", valueOrDefault(config.GetAPIKeyHeader(), "(not set)"), false)
-		printConfigRow("api_key", maskValue(config.GetAPIKeyValue()), true)
+		printPolicyRow(config.KeyAPIURL, config.GetAPIURL())
+		printPolicyRow(config.KeyAPIKeyHeader, config.GetAPIKeyHeader())
+		printPolicyRow(config.KeyAPIKeyValue, config.GetAPIKeyValue())
 		fmt.Println()
 
 		// Default Values
 		fmt.Println(ui.SectionStyle.Render("Defaults"))
-		printConfigRow("default_org", valueOrDefault(config.GetDefaultOrg(), "(not set)"), false)
-		printConfigRow("default_project", valueOrDefault(config.GetDefaultProject(), "(not set)"), false)
+		printPolicyRow(config.KeyDefaultOrg, config.GetDefaultOrg())
+		printPolicyRow(config.KeyDefaultProj, config.GetDefaultProject())
+		fmt.Println()
+
+		// Secret detection policy
+		fmt.Println(ui.SectionStyle.Render("Secret Detection"))
+		printConfigRow(config.KeySecretInclude, valueOrDefault(strings.Join(config.GetSecretIncludePatterns(), "  "), "(not set)"), false)
+		printConfigRow(config.KeySecretExclude, valueOrDefault(strings.Join(config.GetSecretExcludePatterns(), "  "), "(not set)"), false)
+		printConfigRow(config.KeySecretEntropy, fmt.Sprintf("%.1f", policy.EntropyThreshold), false)
 		fmt.Println()
 
 		// Config file location
 		fmt.Printf("%s  %s\n", ui.MutedStyle.Render("Config file:"), ui.ValueStyle.Render(config.ConfigFile()))
+		return nil
 	},
 }
 
+// plainConfigKeys are CLI settings that never hold secrets, even if their names look like they do
+var plainConfigKeys = map[string]bool{
+	config.KeyAPIURL:        true,
+	config.KeyAPIKeyHeader:  true,
+	config.KeyDefaultOrg:    true,
+	config.KeyDefaultProj:   true,
+	config.KeySecretInclude: true,
+	config.KeySecretExclude: true,
+	config.KeySecretEntropy: true,
+}
+
+// isSensitiveConfig checks a CLI config key against the secret policy
+func isSensitiveConfig(policy *secrets.Policy, key, value string) bool {
+	return !plainConfigKeys[key] && policy.IsSensitive(key, value)
+}
+
 // printConfigRow prints a styled config key-value pair
 func printConfigRow(key, value string, masked bool) {
 	keyStr := ui.KeyStyle.Render(key + ":")
@@ -102,16 +178,6 @@ func valueOrDefault(value, defaultVal string) string {
 	return value
 }
 
-func maskValue(value string) string {
-	if value == "" {
-		return "(not set)"
-	}
-	if len(value) > 4 {
-		return value[:4] + "****"
-	}
-	return "****"
-}
-
 func init() {
 	// Add config command to root
 	rootCmd.AddCommand(configCmd)
@@ -120,4 +186,7 @@ func init() {
 	configCmd.AddCommand(configSetCmd)
 	configCmd.AddCommand(configGetCmd)
 	configCmd.AddCommand(configListCmd)
+
+	// Add --show-secrets flag to get command
+	configGetCmd.Flags().Bool("show-secrets", false, "Show the unmasked value for sensitive keys")
 }
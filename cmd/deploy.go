@@ -15,15 +15,45 @@ import (
 var deployCmd = &cobra.Command{
 	Use:   "deploy",
 	Short: "Deploy an agent to an environment",
-	Long:  `Deploy an agent to a target environment using a specific build image.`,
+	Long: `Deploy an agent to a target environment using a specific build image.
+
+The image can be given directly with --image, or taken from a build: --build
+names the build, and --latest-successful picks the newest build that
+succeeded, optionally on one --branch. With none of these, in a terminal, the
+command lists recent successful builds to choose from.
+
+The target is the first environment of the project's deployment pipeline
+unless --env names another. With --env the command checks that the
+environment picked up the new image.
+
+With --wait the command waits until the target environment runs the new
+image with a ready status, and fails if the rollout errors or --timeout
+passes. --probe then sends GET requests to a path on the agent's endpoint
+until it answers with a 2xx status.
+
+Examples:
+  amp deploy --agent myagent --image registry/myagent:abc123
+  amp deploy --agent myagent --build myagent-build-42
+  amp deploy --agent myagent --latest-successful --branch main
+  amp deploy --agent myagent
+  amp deploy --agent myagent --latest-successful --wait --probe /health
+  amp deploy --agent myagent --build myagent-build-42 --env staging --wait`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Get flags
 		org, _ := cmd.Flags().GetString("org")
 		project, _ := cmd.Flags().GetString("project")
 		agent, _ := cmd.Flags().GetString("agent")
 		imageID, _ := cmd.Flags().GetString("image")
+		buildName, _ := cmd.Flags().GetString("build")
+		latest, _ := cmd.Flags().GetBool("latest-successful")
+		branch, _ := cmd.Flags().GetString("branch")
 		envVars, _ := cmd.Flags().GetStringSlice("set-env")
 		output, _ := cmd.Flags().GetString("output")
+		wait, _ := cmd.Flags().GetBool("wait")
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		envName, _ := cmd.Flags().GetString("env")
+		probePath, _ := cmd.Flags().GetString("probe")
+		endpointName, _ := cmd.Flags().GetString("endpoint")
 
 		// Use defaults from config if not provided
 		if org == "" {
@@ -43,8 +73,21 @@ var deployCmd = &cobra.Command{
 		if agent == "" {
 			return fmt.Errorf("agent name is required. Use --agent flag")
 		}
-		if imageID == "" {
-			return fmt.Errorf("image ID is required. Use --image flag")
+		sources := 0
+		for _, set := range []bool{imageID != "", buildName != "", latest} {
+			if set {
+				sources++
+			}
+		}
+		if sources > 1 {
+			return fmt.Errorf("--image, --build and --latest-successful cannot be combined")
+		}
+		if branch != "" && (imageID != "" || buildName != "") {
+			return fmt.Errorf("--branch can only be used with --latest-successful or the build picker")
+		}
+
+		if !wait && (probePath != "" || endpointName != "") {
+			return fmt.Errorf("--probe and --endpoint can only be used with --wait")
 		}
 
 		// Parse environment variables (format: KEY=VALUE)
@@ -70,18 +113,78 @@ var deployCmd = &cobra.Command{
 			config.GetAPIKeyValue(),
 		)
 
+		// Resolve the image from a build when not given directly
+		var build *api.BuildResponse
+		var err error
+		if imageID == "" {
+			sel := deployBuildSelector{Build: buildName, LatestSuccessful: latest, Branch: branch}
+			build, imageID, err = resolveDeployBuild(client, org, project, agent, sel)
+			if err != nil {
+				// Flags were valid; a missing build or cancelled picker is not a usage error
+				cmd.SilenceUsage = true
+				return err
+			}
+		}
+
 		// Build deploy request
 		req := api.DeployAgentRequest{
 			ImageId: imageID,
 			Env:     envList,
 		}
 
+		// Without --env the API picks the environment, and only --wait needs to
+		// know which one it is
+		targetEnv := envName
+		if wait {
+			targetEnv, err = deployTargetEnvironment(client, org, project, envName)
+			if err != nil {
+				return err
+			}
+		}
+
+		var rollout *rolloutWaiter
+		if targetEnv != "" {
+			rollout = &rolloutWaiter{
+				client:    client,
+				org:       org,
+				project:   project,
+				agent:     agent,
+				env:       targetEnv,
+				imageID:   imageID,
+				probePath: probePath,
+				endpoint:  endpointName,
+			}
+			// The current deployment tells a fresh rollout apart from the image already running
+			current, _ := client.GetDeploymentsMap(org, project, agent)
+			rollout.markRequested(current)
+		}
+
 		// Execute deployment
-		err := client.DeployAgent(org, project, agent, req)
+		err = client.DeployAgentToEnvironment(org, project, agent, envName, req)
 		if err != nil {
 			return fmt.Errorf("failed to deploy agent: %w", err)
 		}
 
+		// Wait for the rollout, or at least check that --env picked up the
+		// request, before keeping a local record for amp rollback
+		if wait {
+			if err := waitForRollout(rollout, timeout); err != nil {
+				cmd.SilenceUsage = true
+				if err == errDeployWaitStopped {
+					fmt.Fprintln(os.Stderr, ui.RenderInfo(fmt.Sprintf("Stopped waiting; the rollout goes on. Check it with: amp deployments list --agent %s", agent)))
+				}
+				return err
+			}
+		} else if rollout != nil {
+			if err := rollout.confirmPickedUp(); err != nil {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("failed to deploy agent: %w", err)
+			}
+		}
+		if rollout != nil {
+			recordDeployment(org, project, agent, rollout.env, imageID, build, "deploy")
+		}
+
 		// JSON output
 		if output == "json" {
 			result := map[string]string{
@@ -89,16 +192,40 @@ var deployCmd = &cobra.Command{
 				"agent":   agent,
 				"imageId": imageID,
 			}
+			if rollout != nil {
+				result["environment"] = rollout.env
+				result["deploymentStatus"] = rollout.details.Status
+			}
+			if build != nil {
+				result["build"] = build.Name
+				result["commitId"] = build.CommitID
+			}
 			encoder := json.NewEncoder(os.Stdout)
 			encoder.SetIndent("", "  ")
 			return encoder.Encode(result)
 		}
 
 		// Success message
-		fmt.Println(ui.RenderSuccess("Deployment triggered successfully!"))
+		if wait {
+			fmt.Println(ui.RenderSuccess(fmt.Sprintf("Deployment to %s is ready!", rollout.env)))
+		} else if rollout != nil {
+			fmt.Println(ui.RenderSuccess(fmt.Sprintf("Deployment to %s triggered successfully!", rollout.env)))
+		} else {
+			fmt.Println(ui.RenderSuccess("Deployment triggered successfully!"))
+		}
 		fmt.Println()
 		printDeployRow("Agent:", agent)
 		printDeployRow("Image:", imageID)
+		if rollout != nil {
+			printDeployRow("Environment:", rollout.env)
+			printDeployRow("Status:", ui.StatusCell(strings.ToLower(rollout.details.Status)))
+			if rollout.probeOK {
+				printDeployRow("Probe:", rollout.probePath+" answered")
+			}
+		}
+		if build != nil {
+			printDeployRow("Build:", fmt.Sprintf("%s (commit %s)", build.Name, valueOrDefault(truncateCommit(build.CommitID), "unknown")))
+		}
 		if len(envList) > 0 {
 			printDeployRow("Env Variables:", fmt.Sprintf("%d variable(s) set", len(envList)))
 		}
@@ -124,8 +251,16 @@ func init() {
 
 	// Required flags
 	deployCmd.Flags().StringP("agent", "a", "", "Agent name (required)")
-	deployCmd.Flags().StringP("image", "i", "", "Build image ID (required)")
+	deployCmd.Flags().StringP("image", "i", "", "Build image ID")
 
 	// Optional flags
+	deployCmd.Flags().StringP("build", "b", "", "Deploy the image of this build")
+	deployCmd.Flags().Bool("latest-successful", false, "Deploy the image of the newest successful build")
+	deployCmd.Flags().String("branch", "", "Only consider builds of this branch")
+	deployCmd.Flags().Bool("wait", false, "Wait until the target environment runs the new image")
+	deployCmd.Flags().Duration("timeout", defaultDeployWaitTimeout, "How long --wait waits for the rollout")
+	deployCmd.Flags().String("env", "", "Environment to deploy to (defaults to the pipeline's first environment)")
+	deployCmd.Flags().String("probe", "", "After the rollout, GET this endpoint path until it returns 2xx (e.g. /health)")
+	deployCmd.Flags().String("endpoint", "", "Endpoint to probe when the agent has several")
 	deployCmd.Flags().StringSliceP("set-env", "e", nil, "Environment variables (KEY=VALUE, can be specified multiple times)")
 }
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/Kavirubc/wso2-amp-cli/internal/api"
+	"github.com/Kavirubc/wso2-amp-cli/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/term"
+)
+
+// Limits on how far back deploy looks for successful builds
+const (
+	deployBuildPageSize  = 50
+	deployBuildScanLimit = 500
+	deployPickerLimit    = 20
+)
+
+// deployBuildSelector says which build deploy takes its image from
+type deployBuildSelector struct {
+	// Build is an explicit build name
+	Build string
+	// LatestSuccessful picks the newest succeeded build
+	LatestSuccessful bool
+	// Branch restricts LatestSuccessful and the picker to one branch
+	Branch string
+}
+
+// resolveDeployBuild finds the build to deploy and returns it with its image
+// ID. With no build named and no --latest-successful it shows a picker of
+// recent successful builds, which needs a terminal.
+func resolveDeployBuild(client *api.Client, org, project, agent string, sel deployBuildSelector) (*api.BuildResponse, string, error) {
+	if sel.Build != "" {
+		build, err := client.GetBuild(org, project, agent, sel.Build)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get build: %w", err)
+		}
+		if buildOutcome(build.Status) != buildOutcomeSucceeded {
+			return nil, "", fmt.Errorf("build %s has status %s; only successful builds can be deployed", build.Name, valueOrDefault(build.Status, "unknown"))
+		}
+		if build.ImageID == "" {
+			return nil, "", fmt.Errorf("build %s has no image ID", build.Name)
+		}
+		return &build.BuildResponse, build.ImageID, nil
+	}
+
+	limit := deployPickerLimit
+	if sel.LatestSuccessful {
+		limit = 1
+	} else if !term.IsTerminal(os.Stdin.Fd()) || !term.IsTerminal(os.Stderr.Fd()) {
+		return nil, "", fmt.Errorf("image ID is required. Use --image, --build or --latest-successful flag")
+	}
+
+	builds, err := listSuccessfulBuilds(client, org, project, agent, sel.Branch, limit)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list builds: %w", err)
+	}
+	if len(builds) == 0 {
+		if sel.Branch != "" {
+			return nil, "", fmt.Errorf("no successful builds of branch %s found for agent %s", sel.Branch, agent)
+		}
+		return nil, "", fmt.Errorf("no successful builds found for agent %s", agent)
+	}
+
+	build := builds[0]
+	if !sel.LatestSuccessful {
+		picked, err := pickDeployBuild(agent, builds)
+		if err != nil {
+			return nil, "", err
+		}
+		build = picked
+	}
+
+	imageID, err := buildImageID(client, org, project, agent, build)
+	if err != nil {
+		return nil, "", err
+	}
+	return &build, imageID, nil
+}
+
+// listSuccessfulBuilds returns up to limit succeeded builds, newest first. A
+// build that does not report its branch counts as the agent's configured
+// branch.
+func listSuccessfulBuilds(client *api.Client, org, project, agent, branch string, limit int) ([]api.BuildResponse, error) {
+	defaultBranch := ""
+	if branch != "" {
+		if agentInfo, err := client.GetAgent(org, project, agent); err == nil &&
+			agentInfo.Provisioning != nil && agentInfo.Provisioning.Repository != nil {
+			defaultBranch = agentInfo.Provisioning.Repository.Branch
+		}
+	}
+
+	var builds []api.BuildResponse
+	for offset := 0; offset < deployBuildScanLimit; offset += deployBuildPageSize {
+		page, total, err := client.ListBuilds(org, project, agent, api.ListOptions{Limit: deployBuildPageSize, Offset: offset})
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range page {
+			if buildOutcome(b.Status) != buildOutcomeSucceeded {
+				continue
+			}
+			if branch != "" && valueOrDefault(b.Branch, defaultBranch) != branch {
+				continue
+			}
+			builds = append(builds, b)
+		}
+		if len(page) == 0 || offset+len(page) >= total {
+			break
+		}
+	}
+
+	sort.SliceStable(builds, func(i, j int) bool {
+		return builds[i].StartedAt.After(builds[j].StartedAt)
+	})
+	if len(builds) > limit {
+		builds = builds[:limit]
+	}
+	return builds, nil
+}
+
+// buildImageID returns the image of a build, fetching the build's details
+// when the list did not include it
+func buildImageID(client *api.Client, org, project, agent string, build api.BuildResponse) (string, error) {
+	if build.ImageID != "" {
+		return build.ImageID, nil
+	}
+	details, err := client.GetBuild(org, project, agent, build.Name)
+	if err != nil {
+		return "", fmt.Errorf("failed to get build: %w", err)
+	}
+	if details.ImageID == "" {
+		return "", fmt.Errorf("build %s has no image ID", build.Name)
+	}
+	return details.ImageID, nil
+}
+
+// pickDeployBuild asks the user to choose one of the builds
+func pickDeployBuild(agent string, builds []api.BuildResponse) (api.BuildResponse, error) {
+	rows := make([][]string, len(builds))
+	for i, b := range builds {
+		rows[i] = []string{b.Name, truncateCommit(b.CommitID), valueOrDefault(b.Branch, "-"), formatAge(b.StartedAt)}
+	}
+	title := fmt.Sprintf("%s Choose a build of %s to deploy", ui.IconBuild, agent)
+	model := ui.NewPickerModel(title, []string{"BUILD", "COMMIT", "BRANCH", "AGE"}, rows)
+
+	final, err := tea.NewProgram(model, tea.WithOutput(os.Stderr)).Run()
+	if err != nil {
+		return api.BuildResponse{}, fmt.Errorf("build picker failed: %w", err)
+	}
+	selected := final.(ui.PickerModel).Selected()
+	if selected < 0 {
+		return api.BuildResponse{}, fmt.Errorf("no build selected")
+	}
+	return builds[selected], nil
+}
+
+// formatAge formats how long ago t was, e.g. 5m ago or 3d ago
+func formatAge(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	d := time.Since(t)
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
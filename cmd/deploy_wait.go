@@ -0,0 +1,298 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Kavirubc/wso2-amp-cli/internal/api"
+	"github.com/Kavirubc/wso2-amp-cli/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/term"
+)
+
+// Polling of a rollout started by deploy --wait
+const (
+	deployPollInterval       = 3 * time.Second
+	defaultDeployWaitTimeout = 10 * time.Minute
+	deployProbeRequestLimit  = 10 * time.Second
+
+	// The target environment must report a deploy request within this many polls
+	deployConfirmAttempts = 5
+	deployConfirmInterval = 2 * time.Second
+)
+
+// Rollout states of the target environment
+const (
+	rolloutProgressing = "progressing"
+	rolloutReady       = "ready"
+	rolloutFailed      = "failed"
+)
+
+// rolloutState classifies a deployment against the image being rolled out.
+// Until the environment reports the new image, the rollout is in progress.
+func rolloutState(details api.DeploymentDetails, imageID string) string {
+	if details.ImageID != imageID {
+		return rolloutProgressing
+	}
+	switch s := strings.ToLower(details.Status); {
+	case s == "active", s == "ready", s == "running", s == "healthy", s == "success":
+		return rolloutReady
+	case strings.Contains(s, "fail"), strings.Contains(s, "error"), strings.Contains(s, "crash"):
+		return rolloutFailed
+	}
+	return rolloutProgressing
+}
+
+// deployTargetEnvironment is the environment a deployment goes to: the one
+// given, or the first environment of the project's pipeline
+func deployTargetEnvironment(client *api.Client, org, project, envName string) (string, error) {
+	if envName != "" {
+		return envName, nil
+	}
+	pipeline, err := client.GetProjectDeploymentPipeline(org, project)
+	if err != nil {
+		return "", fmt.Errorf("failed to get deployment pipeline: %w. Use --env to name the target environment", err)
+	}
+	envs := orderPipelineEnvironments(pipeline)
+	if len(envs) == 0 {
+		return "", fmt.Errorf("deployment pipeline %q has no environments. Use --env to name the target environment", pipeline.Name)
+	}
+	return envs[0], nil
+}
+
+// rolloutWaiter polls the target environment until it runs the new image,
+// then optionally probes the agent's endpoint
+type rolloutWaiter struct {
+	client    *api.Client
+	org       string
+	project   string
+	agent     string
+	env       string
+	imageID   string
+	probePath string
+	endpoint  string
+
+	// Deployment of the environment when the request was sent; see markRequested
+	requested   time.Time
+	before      *api.DeploymentDetails
+	beforeKnown bool
+	started     bool
+
+	// State of the last poll
+	details *api.DeploymentDetails
+	state   string
+	probing bool
+	probeOK bool
+	failure error
+}
+
+// markRequested notes the environment's deployment just before the deploy
+// request is sent. deployments is nil when it could not be read.
+func (w *rolloutWaiter) markRequested(deployments map[string]api.DeploymentDetails) {
+	w.requested = time.Now()
+	w.beforeKnown = deployments != nil
+	if d, ok := deployments[w.env]; ok {
+		w.before = &d
+	}
+}
+
+// rolloutStarted reports whether the environment has picked up the request.
+// An environment that already ran the image looks ready at once, so then the
+// deployment time or status must have changed since the request.
+func (w *rolloutWaiter) rolloutStarted(details api.DeploymentDetails) bool {
+	if w.started {
+		return true
+	}
+	switch {
+	case w.requested.IsZero():
+		w.started = true
+	case !w.beforeKnown:
+		// The API reports deployment times in whole seconds
+		w.started = details.LastDeployed != nil && !details.LastDeployed.Before(w.requested.Truncate(time.Second))
+	case w.before == nil || w.before.ImageID != w.imageID:
+		w.started = true
+	case details.LastDeployed != nil && (w.before.LastDeployed == nil || details.LastDeployed.After(*w.before.LastDeployed)):
+		w.started = true
+	case !strings.EqualFold(details.Status, w.before.Status):
+		w.started = true
+	}
+	return w.started
+}
+
+// confirmPickedUp polls until the target environment reports the new image.
+// The deploy request is only accepted by the API, which could still have
+// routed it to another environment.
+func (w *rolloutWaiter) confirmPickedUp() error {
+	var err error
+	for attempt := 0; attempt < deployConfirmAttempts; attempt++ {
+		if attempt > 0 {
+			time.Sleep(deployConfirmInterval)
+		}
+		var deployments map[string]api.DeploymentDetails
+		deployments, err = w.client.GetDeploymentsMap(w.org, w.project, w.agent)
+		if err != nil {
+			continue
+		}
+		if details, ok := deployments[w.env]; ok && details.ImageID == w.imageID && w.rolloutStarted(details) {
+			w.details = &details
+			return nil
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("could not confirm that %s picked up the deployment: %w", w.env, err)
+	}
+	return fmt.Errorf("%s does not report %s after the deploy request; it may have gone to another environment. Check with: amp deployments list --agent %s",
+		w.env, w.imageID, w.agent)
+}
+
+// poll advances the wait by one step and describes where it stands
+func (w *rolloutWaiter) poll() (string, error) {
+	if w.probing {
+		if err := w.probe(); err != nil {
+			return fmt.Sprintf("probing %s", w.probePath), err
+		}
+		w.probeOK = true
+		return fmt.Sprintf("%s answered", w.probePath), nil
+	}
+
+	deployments, err := w.client.GetDeploymentsMap(w.org, w.project, w.agent)
+	if err != nil {
+		return "", err
+	}
+	details, ok := deployments[w.env]
+	if !ok {
+		return fmt.Sprintf("waiting for %s to report the deployment", w.env), nil
+	}
+	w.details = &details
+	w.state = rolloutState(details, w.imageID)
+	if details.ImageID == w.imageID && !w.rolloutStarted(details) {
+		w.state = rolloutProgressing
+		return fmt.Sprintf("waiting for %s to pick up the deployment", w.env), nil
+	}
+
+	switch w.state {
+	case rolloutFailed:
+		w.failure = fmt.Errorf("deployment to %s failed with status %s", w.env, details.Status)
+	case rolloutReady:
+		if w.probePath != "" {
+			w.probing = true
+			return fmt.Sprintf("%s is %s; probing %s", w.env, details.Status, w.probePath), nil
+		}
+	case rolloutProgressing:
+		if details.ImageID != w.imageID {
+			return fmt.Sprintf("%s still runs %s", w.env, valueOrDefault(details.ImageID, "no image")), nil
+		}
+	}
+	return fmt.Sprintf("%s is %s", w.env, valueOrDefault(details.Status, "pending")), nil
+}
+
+// done reports whether the wait is over, successfully or not
+func (w *rolloutWaiter) done() bool {
+	if w.failure != nil {
+		return true
+	}
+	if w.probePath != "" {
+		return w.probeOK
+	}
+	return w.state == rolloutReady
+}
+
+// probe sends one GET to the agent's endpoint and expects a 2xx answer
+func (w *rolloutWaiter) probe() error {
+	ep, err := resolveAgentEndpoint(w.client, w.org, w.project, w.agent, w.env, w.endpoint)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodGet, joinEndpointPath(ep.URL, w.probePath), nil)
+	if err != nil {
+		return err
+	}
+	// Agent endpoints usually require a token; a public health check works without
+	if token, err := agentBearerToken(w.client, w.org, w.project, w.agent, "", false); err == nil {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	httpClient := &http.Client{Timeout: deployProbeRequestLimit}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("probe failed: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("probe returned %s", resp.Status)
+	}
+	return nil
+}
+
+// errDeployWaitStopped is returned when the user stops waiting in the spinner view
+var errDeployWaitStopped = fmt.Errorf("stopped waiting")
+
+// waitForRollout waits until the rollout is ready, has failed or the timeout
+// passes. In a terminal it shows a spinner; otherwise it prints each change
+// of state to stderr.
+func waitForRollout(w *rolloutWaiter, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	timedOut := false
+	var lastErr error
+	step := func() (string, bool, error) {
+		message, err := w.poll()
+		lastErr = err
+		if w.done() {
+			return message, true, err
+		}
+		if time.Now().After(deadline) {
+			timedOut = true
+			return message, true, err
+		}
+		return message, false, err
+	}
+
+	if term.IsTerminal(os.Stderr.Fd()) {
+		title := fmt.Sprintf("%s Rolling out %s to %s", ui.IconAgent, w.agent, w.env)
+		poll := func() (ui.WaitStatus, error) {
+			message, done, err := step()
+			return ui.WaitStatus{Message: message, Done: done}, err
+		}
+		final, err := tea.NewProgram(ui.NewWaitModel(title, poll, deployPollInterval), tea.WithOutput(os.Stderr)).Run()
+		if err != nil {
+			return fmt.Errorf("progress view failed: %w", err)
+		}
+		if final.(ui.WaitModel).Interrupted() {
+			return errDeployWaitStopped
+		}
+	} else {
+		last := ""
+		for {
+			message, done, err := step()
+			if err != nil {
+				message = fmt.Sprintf("%s (%v)", message, err)
+			}
+			if message != last {
+				fmt.Fprintf(os.Stderr, "%s  %s\n", time.Now().Format("15:04:05"), message)
+				last = message
+			}
+			if done {
+				break
+			}
+			time.Sleep(deployPollInterval)
+		}
+	}
+
+	switch {
+	case w.failure != nil:
+		return w.failure
+	case timedOut:
+		reason := fmt.Sprintf("%s did not become ready", w.env)
+		if w.probing {
+			reason = fmt.Sprintf("%s did not answer", w.probePath)
+		}
+		if lastErr != nil {
+			reason += ": " + lastErr.Error()
+		}
+		return fmt.Errorf("timed out after %s: %s", timeout, reason)
+	}
+	return nil
+}
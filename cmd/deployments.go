@@ -15,7 +15,8 @@ import (
 var deploymentsCmd = &cobra.Command{
 	Use:   "deployments",
 	Short: "Manage agent deployments",
-	Long:  `Commands for listing deployments and viewing endpoints for deployed agents.`,
+	Long: `Commands for listing deployments, viewing endpoints for deployed agents and
+comparing deployments across a project's environments.`,
 }
 
 var deploymentsListCmd = &cobra.Command{
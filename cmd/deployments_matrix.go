@@ -0,0 +1,356 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Kavirubc/wso2-amp-cli/internal/api"
+	"github.com/Kavirubc/wso2-amp-cli/internal/config"
+	"github.com/Kavirubc/wso2-amp-cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// Export formats accepted by --format on deployments matrix
+const (
+	matrixFormatCSV      = "csv"
+	matrixFormatMarkdown = "markdown"
+)
+
+// matrixSkewMarker flags a cell whose image differs from the environment it is promoted from
+const matrixSkewMarker = "≠"
+
+// matrixCell is the deployment of one agent to one environment
+type matrixCell struct {
+	ImageID      string     `json:"imageId"`
+	Status       string     `json:"status"`
+	LastDeployed *time.Time `json:"lastDeployed,omitempty"`
+	// SkewFrom names the environment this one is promoted from when it runs a different image
+	SkewFrom string `json:"skewFrom,omitempty"`
+}
+
+// matrixRow holds the deployments of one agent, keyed by environment
+type matrixRow struct {
+	Agent       string                `json:"agent"`
+	Deployments map[string]matrixCell `json:"deployments"`
+}
+
+// deploymentMatrix is the JSON shape of deployments matrix
+type deploymentMatrix struct {
+	Project      string            `json:"project"`
+	Environments []string          `json:"environments"`
+	Agents       []matrixRow       `json:"agents"`
+	Errors       map[string]string `json:"errors,omitempty"`
+}
+
+// promotionSources maps each environment of the pipeline to the environment
+// it is promoted from
+func promotionSources(pipeline *api.DeploymentPipelineResponse) map[string]string {
+	sources := make(map[string]string)
+	if pipeline == nil {
+		return sources
+	}
+	for _, path := range pipeline.PromotionPaths {
+		for _, t := range path.TargetEnvironmentRefs {
+			if _, ok := sources[t.Name]; !ok {
+				sources[t.Name] = path.SourceEnvironmentRef
+			}
+		}
+	}
+	return sources
+}
+
+// newDeploymentMatrix arranges the deployments of each agent along the
+// pipeline. Environments outside the pipeline follow it in name order.
+func newDeploymentMatrix(project string, agents []string, deployments []map[string]api.DeploymentDetails, errs []error, pipeline *api.DeploymentPipelineResponse) deploymentMatrix {
+	m := deploymentMatrix{Project: project, Environments: orderPipelineEnvironments(pipeline)}
+	sources := promotionSources(pipeline)
+
+	known := make(map[string]bool)
+	for _, env := range m.Environments {
+		known[env] = true
+	}
+	var extra []string
+	for i := range agents {
+		for env := range deployments[i] {
+			if !known[env] {
+				known[env] = true
+				extra = append(extra, env)
+			}
+		}
+	}
+	sort.Strings(extra)
+	m.Environments = append(m.Environments, extra...)
+
+	for i, agent := range agents {
+		if errs[i] != nil {
+			if m.Errors == nil {
+				m.Errors = make(map[string]string)
+			}
+			m.Errors[agent] = errs[i].Error()
+			continue
+		}
+		row := matrixRow{Agent: agent, Deployments: make(map[string]matrixCell)}
+		for _, env := range m.Environments {
+			d, ok := deployments[i][env]
+			if !ok {
+				continue
+			}
+			cell := matrixCell{ImageID: d.ImageID, Status: d.Status, LastDeployed: d.LastDeployed}
+			// Skew is only reported when the agent also runs in the promotion source
+			if from, ok := sources[env]; ok {
+				if src, ok := deployments[i][from]; ok && src.ImageID != d.ImageID {
+					cell.SkewFrom = from
+				}
+			}
+			row.Deployments[env] = cell
+		}
+		m.Agents = append(m.Agents, row)
+	}
+	return m
+}
+
+// matrixImageLabel shortens an image reference to its tag or digest, since
+// the row already names the agent, e.g. registry/myagent:abc123 becomes abc123
+func matrixImageLabel(imageID string) string {
+	label := imageID
+	if i := strings.LastIndex(label, "/"); i >= 0 {
+		label = label[i+1:]
+	}
+	if i := strings.Index(label, "@"); i >= 0 {
+		label = label[i+1:]
+	} else if i := strings.LastIndex(label, ":"); i >= 0 {
+		label = label[i+1:]
+	}
+	if len(label) > 19 {
+		label = label[:19] + "..."
+	}
+	return label
+}
+
+// lastDeployedAge formats when a deployment last changed
+func lastDeployedAge(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return formatAge(*t)
+}
+
+// printDeploymentMatrix renders the matrix as a table with one row per agent
+func printDeploymentMatrix(m deploymentMatrix) {
+	title := fmt.Sprintf("%s Deployments of %s", ui.IconDeploy, m.Project)
+	if len(m.Agents) == 0 {
+		fmt.Println(ui.RenderWarning("No agents found."))
+		return
+	}
+	if len(m.Environments) == 0 {
+		fmt.Println(ui.RenderWarning("No deployments found."))
+		return
+	}
+
+	headers := append([]string{"AGENT"}, m.Environments...)
+	rows := make([][]string, len(m.Agents))
+	skewed := false
+	for i, row := range m.Agents {
+		cells := []string{row.Agent}
+		for _, env := range m.Environments {
+			cell, ok := row.Deployments[env]
+			if !ok {
+				cells = append(cells, ui.MutedStyle.Render("—"))
+				continue
+			}
+			image := matrixImageLabel(cell.ImageID)
+			if cell.SkewFrom != "" {
+				image = ui.WarningStyle.Render(matrixSkewMarker + " " + image)
+				skewed = true
+			}
+			cells = append(cells, image+"\n"+ui.StatusCell(strings.ToLower(cell.Status))+" "+ui.MutedStyle.Render(lastDeployedAge(cell.LastDeployed)))
+		}
+		rows[i] = cells
+	}
+
+	fmt.Println(ui.RenderTableWithTitle(title, headers, rows))
+	if skewed {
+		fmt.Println(ui.MutedStyle.Render(matrixSkewMarker + " runs a different image than the environment it is promoted from"))
+	}
+	fmt.Println()
+}
+
+// writeDeploymentMatrixCSV writes one record per deployed agent and environment
+func writeDeploymentMatrixCSV(w io.Writer, m deploymentMatrix) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"agent", "environment", "image_id", "status", "last_deployed", "skew_from"}); err != nil {
+		return err
+	}
+	for _, row := range m.Agents {
+		for _, env := range m.Environments {
+			cell, ok := row.Deployments[env]
+			if !ok {
+				continue
+			}
+			lastDeployed := ""
+			if cell.LastDeployed != nil {
+				lastDeployed = cell.LastDeployed.UTC().Format(time.RFC3339)
+			}
+			if err := cw.Write([]string{row.Agent, env, cell.ImageID, cell.Status, lastDeployed, cell.SkewFrom}); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// markdownCell escapes characters that would break a Markdown table cell
+func markdownCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+// writeDeploymentMatrixMarkdown writes the matrix as a Markdown table, e.g.
+// for a release note or pull request
+func writeDeploymentMatrixMarkdown(w io.Writer, m deploymentMatrix) error {
+	var b strings.Builder
+	b.WriteString("| Agent |")
+	for _, env := range m.Environments {
+		b.WriteString(" " + markdownCell(env) + " |")
+	}
+	b.WriteString("\n|---|")
+	b.WriteString(strings.Repeat("---|", len(m.Environments)))
+	b.WriteString("\n")
+
+	skewed := false
+	for _, row := range m.Agents {
+		b.WriteString("| " + markdownCell(row.Agent) + " |")
+		for _, env := range m.Environments {
+			cell, ok := row.Deployments[env]
+			if !ok {
+				b.WriteString(" — |")
+				continue
+			}
+			marker := ""
+			if cell.SkewFrom != "" {
+				marker = matrixSkewMarker + " "
+				skewed = true
+			}
+			fmt.Fprintf(&b, " %s`%s` %s, %s |", marker, markdownCell(matrixImageLabel(cell.ImageID)),
+				markdownCell(valueOrDefault(cell.Status, "unknown")), lastDeployedAge(cell.LastDeployed))
+		}
+		b.WriteString("\n")
+	}
+	if skewed {
+		b.WriteString("\n" + matrixSkewMarker + " runs a different image than the environment it is promoted from\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+var deploymentsMatrixCmd = &cobra.Command{
+	Use:   "matrix",
+	Short: "Show every agent's deployments across the project's environments",
+	Long: `Show a grid of the project's agents and environments. Each cell shows the
+image tag, status and age of the agent's deployment to that environment.
+Environments are ordered along the project's deployment pipeline.
+
+A cell is marked with ≠ when the agent runs a different image than in the
+environment it is promoted from, which shows what is waiting to be
+promoted. Agents whose deployments cannot be read are reported on stderr
+and left out of the grid.
+
+Examples:
+  amp deployments matrix --project myproject
+  amp deployments matrix --format markdown > DEPLOYMENTS.md
+  amp deployments matrix --format csv
+  amp deployments matrix --output json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flags
+		org, _ := cmd.Flags().GetString("org")
+		project, _ := cmd.Flags().GetString("project")
+		format, _ := cmd.Flags().GetString("format")
+		concurrency, _ := cmd.Flags().GetInt("concurrency")
+		output, _ := cmd.Flags().GetString("output")
+
+		switch format {
+		case "", matrixFormatCSV, matrixFormatMarkdown:
+		default:
+			return fmt.Errorf("invalid --format %q: must be %s or %s", format, matrixFormatCSV, matrixFormatMarkdown)
+		}
+		if format != "" && output == "json" {
+			return fmt.Errorf("--format cannot be combined with --output json")
+		}
+
+		// Use defaults from config if not provided
+		if org == "" {
+			org = config.GetDefaultOrg()
+		}
+		if project == "" {
+			project = config.GetDefaultProject()
+		}
+
+		// Validate required fields
+		if org == "" {
+			return fmt.Errorf("organization is required. Use --org flag or set default with: amp config set default_org <name>")
+		}
+		if project == "" {
+			return fmt.Errorf("project is required. Use --project flag or set default with: amp config set default_project <name>")
+		}
+
+		// Create API client
+		client := api.NewClient(
+			config.GetAPIURL(),
+			config.GetAPIKeyHeader(),
+			config.GetAPIKeyValue(),
+		)
+
+		agents, err := listAllAgentNames(client, org, project)
+		if err != nil {
+			return fmt.Errorf("failed to list agents: %w", err)
+		}
+
+		// Without a pipeline the environments are shown in name order
+		pipeline, err := client.GetProjectDeploymentPipeline(org, project)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, ui.RenderWarning(fmt.Sprintf("Environments not in pipeline order: %v", err)))
+		}
+
+		// Fetch every agent's deployments
+		deployments := make([]map[string]api.DeploymentDetails, len(agents))
+		fetchErrs := make([]error, len(agents))
+		runBounded(len(agents), concurrency, func(i int) {
+			deployments[i], fetchErrs[i] = client.GetDeploymentsMap(org, project, agents[i])
+		})
+
+		m := newDeploymentMatrix(project, agents, deployments, fetchErrs, pipeline)
+		for _, agent := range agents {
+			if msg, ok := m.Errors[agent]; ok {
+				fmt.Fprintln(os.Stderr, ui.RenderWarning(fmt.Sprintf("%s: %s", agent, msg)))
+			}
+		}
+
+		switch {
+		case format == matrixFormatCSV:
+			return writeDeploymentMatrixCSV(os.Stdout, m)
+		case format == matrixFormatMarkdown:
+			return writeDeploymentMatrixMarkdown(os.Stdout, m)
+		case output == "json":
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(m)
+		}
+
+		printDeploymentMatrix(m)
+		return nil
+	},
+}
+
+func init() {
+	deploymentsCmd.AddCommand(deploymentsMatrixCmd)
+
+	deploymentsMatrixCmd.Flags().String("format", "", "Export format: csv or markdown")
+	deploymentsMatrixCmd.Flags().Int("concurrency", defaultLogsConcurrency, "Number of agents queried at once")
+}
@@ -0,0 +1,335 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/Kavirubc/wso2-amp-cli/internal/api"
+	"github.com/Kavirubc/wso2-amp-cli/internal/config"
+	"github.com/Kavirubc/wso2-amp-cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// defaultLogsConcurrency is the number of agents queried at once
+const defaultLogsConcurrency = 4
+
+// projectLogsResponse is the JSON shape of aggregated project logs
+type projectLogsResponse struct {
+	Project     string          `json:"project"`
+	Environment string          `json:"environment"`
+	Agents      []string        `json:"agents"`
+	Logs        []agentLogEntry `json:"logs"`
+	TotalCount  int             `json:"totalCount"`
+}
+
+var logsCmd = &cobra.Command{
+	Use:   "logs",
+	Short: "View runtime logs for all agents in a project",
+	Long: `Fetch runtime logs from every agent in a project and merge them into
+a single timeline ordered by timestamp. Each line is prefixed with the
+name of the agent that produced it.
+
+Examples:
+  amp logs --project myproject --env development
+  amp logs --env dev --since 1h --level ERROR,WARN
+  amp logs --env dev --search "connection refused"
+  amp logs --env dev --follow
+  amp logs --env dev --follow --output json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flags
+		org, _ := cmd.Flags().GetString("org")
+		project, _ := cmd.Flags().GetString("project")
+		envName, _ := cmd.Flags().GetString("env")
+		since, _ := cmd.Flags().GetString("since")
+		level, _ := cmd.Flags().GetString("level")
+		search, _ := cmd.Flags().GetString("search")
+		limit, _ := cmd.Flags().GetInt("limit")
+		output, _ := cmd.Flags().GetString("output")
+		follow, _ := cmd.Flags().GetBool("follow")
+		interval, _ := cmd.Flags().GetDuration("interval")
+		concurrency, _ := cmd.Flags().GetInt("concurrency")
+		until, _ := cmd.Flags().GetString("until")
+		start, _ := cmd.Flags().GetString("start")
+		end, _ := cmd.Flags().GetString("end")
+
+		opts, err := readLogOptions(cmd)
+		if err != nil {
+			return err
+		}
+
+		// Use defaults from config if not provided
+		if org == "" {
+			org = config.GetDefaultOrg()
+		}
+		if project == "" {
+			project = config.GetDefaultProject()
+		}
+
+		// Validate required fields
+		if org == "" {
+			return fmt.Errorf("organization is required. Use --org flag or set default with: amp config set default_org <name>")
+		}
+		if project == "" {
+			return fmt.Errorf("project is required. Use --project flag or set default with: amp config set default_project <name>")
+		}
+		if envName == "" {
+			return fmt.Errorf("environment name is required. Use --env flag")
+		}
+		if limit < 1 || limit > 1000 {
+			return fmt.Errorf("limit must be between 1 and 1000")
+		}
+		if concurrency < 1 {
+			return fmt.Errorf("concurrency must be at least 1")
+		}
+		if follow && (until != "" || end != "") {
+			return fmt.Errorf("--follow cannot be combined with --until or --end")
+		}
+
+		// Build the log request shared by all agents
+		req := api.RuntimeLogRequest{
+			EnvironmentName: envName,
+			Limit:           limit,
+			SortOrder:       "desc",
+		}
+		req.StartTime, req.EndTime, err = resolveLogWindow(since, until, start, end)
+		if err != nil {
+			return err
+		}
+		if level != "" {
+			levels := strings.Split(level, ",")
+			for i, l := range levels {
+				levels[i] = strings.TrimSpace(strings.ToUpper(l))
+			}
+			req.LogLevels = levels
+		}
+		if search != "" {
+			req.SearchPhrase = search
+		}
+
+		client := api.NewClient(
+			config.GetAPIURL(),
+			config.GetAPIKeyHeader(),
+			config.GetAPIKeyValue(),
+		)
+
+		agentNames, err := listAllAgentNames(client, org, project)
+		if err != nil {
+			return fmt.Errorf("failed to list agents: %w", err)
+		}
+		if len(agentNames) == 0 {
+			if output == "json" {
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(projectLogsResponse{Project: project, Environment: envName, Agents: []string{}, Logs: []agentLogEntry{}})
+			}
+			fmt.Println(ui.RenderWarning(fmt.Sprintf("No agents found in project %s.", project)))
+			return nil
+		}
+
+		// Fan out to every agent with bounded concurrency
+		perAgent := make([][]api.LogEntry, len(agentNames))
+		errs := make([]error, len(agentNames))
+		runBounded(len(agentNames), concurrency, func(i int) {
+			logs, err := client.GetAgentRuntimeLogs(org, project, agentNames[i], req)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			perAgent[i] = logs.Logs
+		})
+
+		// A failing agent should not hide the logs of the others
+		failures := 0
+		for i, err := range errs {
+			if err != nil {
+				failures++
+				fmt.Fprintln(os.Stderr, ui.RenderWarning(fmt.Sprintf("%s: failed to get runtime logs: %v", agentNames[i], err)))
+			}
+		}
+		if failures == len(agentNames) {
+			return fmt.Errorf("failed to get runtime logs: %w", errs[0])
+		}
+
+		// Merge by timestamp and keep the newest entries up to the limit
+		var merged []agentLogEntry
+		for i, entries := range perAgent {
+			merged = append(merged, tagLogEntries(agentNames[i], entries)...)
+		}
+		sortAgentLogEntries(merged)
+		if len(merged) > limit {
+			merged = merged[len(merged)-limit:]
+		}
+
+		printer := newAgentLogPrinter(agentNames)
+
+		if follow {
+			return followProjectLogs(client, org, project, envName, req, agentNames, perAgent, errs, merged, output, interval, concurrency, printer, opts)
+		}
+
+		// Apply local --grep/--exclude filters
+		merged = opts.filter(merged)
+
+		// Plain formats and file output
+		if opts.usesWriter() {
+			return writeLogs(opts, output, true, merged)
+		}
+
+		if output == "json" {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			if merged == nil {
+				merged = []agentLogEntry{}
+			}
+			return encoder.Encode(projectLogsResponse{
+				Project:     project,
+				Environment: envName,
+				Agents:      agentNames,
+				Logs:        merged,
+				TotalCount:  len(merged),
+			})
+		}
+
+		fmt.Println(ui.TitleStyle.Render(fmt.Sprintf("%s Project Logs: %s (%s)", ui.IconLogs, project, envName)))
+		fmt.Println()
+
+		if len(merged) == 0 {
+			fmt.Println(ui.RenderWarning("No logs found for the specified criteria."))
+			return nil
+		}
+
+		for _, entry := range merged {
+			printer.print(entry)
+		}
+
+		fmt.Println()
+		fmt.Println(ui.MutedStyle.Render(fmt.Sprintf("Showing %d log entries from %d agents", len(merged), len(agentNames))))
+		return nil
+	},
+}
+
+// followProjectLogs prints the merged initial entries and then streams new ones from every agent
+func followProjectLogs(client *api.Client, org, project, envName string, req api.RuntimeLogRequest, agentNames []string, perAgent [][]api.LogEntry, errs []error, initial []agentLogEntry, output string, interval time.Duration, concurrency int, printer *agentLogPrinter, opts *logOptions) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var sinceStart time.Time
+	if req.StartTime != "" {
+		if t, err := time.Parse(time.RFC3339, req.StartTime); err == nil {
+			sinceStart = t
+		}
+	}
+
+	// One follower per agent, each continuing after the newest entry it returned
+	now := time.Now()
+	followers := make([]*logFollower, len(agentNames))
+	for i, name := range agentNames {
+		start := sinceStart
+		if start.IsZero() && (len(perAgent[i]) == 0 || errs[i] != nil) {
+			start = now
+		}
+		recent := append([]api.LogEntry(nil), perAgent[i]...)
+		sortLogEntries(recent)
+		followers[i] = newLogFollower(client, org, project, name, req, start)
+		followers[i].prime(recent)
+	}
+
+	initial = opts.filter(initial)
+
+	// Plain formats, file output and JSON are streamed entry by entry
+	if opts.usesWriter() || output == "json" {
+		w, err := newLogWriter(opts, output, true)
+		if err != nil {
+			return err
+		}
+		defer w.Close()
+		if err := w.Write(initial); err != nil {
+			return err
+		}
+		return followLogs(ctx, followers, interval, concurrency, func(entries []agentLogEntry) error {
+			return w.Write(opts.filter(entries))
+		})
+	}
+
+	fmt.Println(ui.TitleStyle.Render(fmt.Sprintf("%s Project Logs: %s (%s)", ui.IconLogs, project, envName)))
+	fmt.Println(ui.MutedStyle.Render(fmt.Sprintf("Following new log entries from %d agents. Press Ctrl+C to stop.", len(agentNames))))
+	fmt.Println()
+	for _, entry := range initial {
+		printer.print(entry)
+	}
+
+	return followLogs(ctx, followers, interval, concurrency, func(entries []agentLogEntry) error {
+		for _, entry := range opts.filter(entries) {
+			printer.print(entry)
+		}
+		return nil
+	})
+}
+
+// listAllAgentNames pages through every agent in a project
+func listAllAgentNames(client *api.Client, org, project string) ([]string, error) {
+	const pageSize = 100
+	var names []string
+	for offset := 0; ; offset += pageSize {
+		agents, total, err := client.ListAgents(org, project, api.ListOptions{Limit: pageSize, Offset: offset})
+		if err != nil {
+			return nil, err
+		}
+		for _, agent := range agents {
+			names = append(names, agent.Name)
+		}
+		if len(agents) == 0 || len(names) >= total {
+			return names, nil
+		}
+	}
+}
+
+// agentLogPrinter prints log lines prefixed with a colour-coded agent name
+type agentLogPrinter struct {
+	width  int
+	colors map[string]int
+}
+
+// newAgentLogPrinter assigns each agent a stable colour and pads names to equal width
+func newAgentLogPrinter(agentNames []string) *agentLogPrinter {
+	p := &agentLogPrinter{colors: make(map[string]int)}
+	for i, name := range agentNames {
+		p.colors[name] = i
+		if len(name) > p.width {
+			p.width = len(name)
+		}
+	}
+	return p
+}
+
+// print prints one entry as "[time] agent [LEVEL] message"
+func (p *agentLogPrinter) print(entry agentLogEntry) {
+	timestamp := ui.FormatLogTimestamp(entry.Timestamp)
+	prefix := ui.AgentLogStyle(p.colors[entry.Agent]).Render(fmt.Sprintf("%-*s", p.width, entry.Agent))
+	levelPrefix := ui.FormatLogLevel(entry.LogLevel)
+	if levelPrefix != "" {
+		fmt.Printf("[%s] %s %s %s\n", timestamp, prefix, levelPrefix, entry.Log)
+	} else {
+		fmt.Printf("[%s] %s %s\n", timestamp, prefix, entry.Log)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(logsCmd)
+
+	logsCmd.Flags().StringP("env", "e", "", "Environment name (required)")
+	logsCmd.Flags().String("since", "", "Show logs since duration (e.g., 1h, 24h, 7d)")
+	logsCmd.Flags().String("level", "", "Filter by log levels (comma-separated: ERROR,WARN,INFO,DEBUG)")
+	logsCmd.Flags().String("search", "", "Search phrase to filter logs")
+	logsCmd.Flags().Int("limit", 100, "Maximum number of log entries to return")
+	logsCmd.Flags().BoolP("follow", "f", false, "Stream new log entries until interrupted")
+	logsCmd.Flags().Duration("interval", defaultFollowInterval, "Polling interval when following logs")
+	logsCmd.Flags().Int("concurrency", defaultLogsConcurrency, "Maximum number of agents queried at once")
+	addLogWindowFlags(logsCmd)
+	addLogOutputFlags(logsCmd)
+}
@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Kavirubc/wso2-amp-cli/internal/api"
+	"github.com/Kavirubc/wso2-amp-cli/internal/ui"
+)
+
+// Polling limits for --follow
+const (
+	defaultFollowInterval = 2 * time.Second
+	maxFollowBackoff      = 30 * time.Second
+)
+
+// logFollower polls runtime logs for one agent with a moving startTime cursor.
+// Entries that share the cursor timestamp are remembered so they are not
+// emitted twice when the next poll starts at that same timestamp.
+type logFollower struct {
+	client    *api.Client
+	org       string
+	project   string
+	agentName string
+	req       api.RuntimeLogRequest
+	cursor    time.Time
+	seen      map[string]bool
+
+	// end bounds each poll when set; otherwise polls read up to now
+	end time.Time
+	// lastCount is the number of entries the last poll returned, before de-duplication
+	lastCount int
+}
+
+// newLogFollower creates a follower that starts reading at the given time
+func newLogFollower(client *api.Client, org, project, agentName string, req api.RuntimeLogRequest, start time.Time) *logFollower {
+	return &logFollower{
+		client:    client,
+		org:       org,
+		project:   project,
+		agentName: agentName,
+		req:       req,
+		cursor:    start,
+		seen:      make(map[string]bool),
+	}
+}
+
+// prime records already-displayed entries so polling continues after the newest one
+func (f *logFollower) prime(entries []api.LogEntry) {
+	for _, entry := range entries {
+		t := parseLogTime(entry.Timestamp, f.cursor)
+		if t.After(f.cursor) {
+			f.cursor = t
+			f.seen = make(map[string]bool)
+		}
+		if t.Equal(f.cursor) {
+			f.seen[logEntryKey(entry)] = true
+		}
+	}
+}
+
+// poll fetches entries newer than the cursor and returns them oldest first
+func (f *logFollower) poll() ([]api.LogEntry, error) {
+	req := f.req
+	end := f.end
+	if end.IsZero() {
+		end = time.Now()
+	}
+	req.StartTime = f.cursor.UTC().Format(time.RFC3339Nano)
+	req.EndTime = end.UTC().Format(time.RFC3339Nano)
+	req.SortOrder = "asc"
+
+	logs, err := f.client.GetAgentRuntimeLogs(f.org, f.project, f.agentName, req)
+	if err != nil {
+		return nil, err
+	}
+	f.lastCount = len(logs.Logs)
+
+	entries := append([]api.LogEntry(nil), logs.Logs...)
+	sortLogEntries(entries)
+
+	var fresh []api.LogEntry
+	for _, entry := range entries {
+		t := parseLogTime(entry.Timestamp, f.cursor)
+		key := logEntryKey(entry)
+		if t.Before(f.cursor) || (t.Equal(f.cursor) && f.seen[key]) {
+			continue
+		}
+		if t.After(f.cursor) {
+			// Cursor moves forward; only entries at the new timestamp need remembering
+			f.cursor = t
+			f.seen = make(map[string]bool)
+		}
+		f.seen[key] = true
+		fresh = append(fresh, entry)
+	}
+	return fresh, nil
+}
+
+// sortLogEntries orders entries oldest first, keeping server order for equal timestamps
+func sortLogEntries(entries []api.LogEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return parseLogTime(entries[i].Timestamp, time.Time{}).Before(parseLogTime(entries[j].Timestamp, time.Time{}))
+	})
+}
+
+// parseLogTime parses a log timestamp, falling back to the given time
+func parseLogTime(timestamp string, fallback time.Time) time.Time {
+	if t, err := time.Parse(time.RFC3339Nano, timestamp); err == nil {
+		return t
+	}
+	return fallback
+}
+
+// logEntryKey identifies a log entry for de-duplication
+func logEntryKey(entry api.LogEntry) string {
+	return entry.Timestamp + "\x00" + entry.LogLevel + "\x00" + entry.Log
+}
+
+// agentLogEntry is a log entry tagged with the agent that produced it
+type agentLogEntry struct {
+	Agent string `json:"agent,omitempty"`
+	api.LogEntry
+}
+
+// tagLogEntries tags entries with their agent name
+func tagLogEntries(agentName string, entries []api.LogEntry) []agentLogEntry {
+	tagged := make([]agentLogEntry, len(entries))
+	for i, entry := range entries {
+		tagged[i] = agentLogEntry{Agent: agentName, LogEntry: entry}
+	}
+	return tagged
+}
+
+// sortAgentLogEntries merges entries from several agents oldest first
+func sortAgentLogEntries(entries []agentLogEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return parseLogTime(entries[i].Timestamp, time.Time{}).Before(parseLogTime(entries[j].Timestamp, time.Time{}))
+	})
+}
+
+// runBounded calls fn for every index in [0, n) with at most limit calls in flight
+func runBounded(n, limit int, fn func(i int)) {
+	if limit < 1 {
+		limit = 1
+	}
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+// followLogs polls every follower until ctx is cancelled, handing new entries to emit
+// merged by timestamp. At most concurrency polls run at once. Errors are reported on
+// stderr and retried with exponential backoff.
+func followLogs(ctx context.Context, followers []*logFollower, interval time.Duration, concurrency int, emit func(entries []agentLogEntry) error) error {
+	if interval <= 0 {
+		interval = defaultFollowInterval
+	}
+	delay := time.Duration(0)
+	backoff := interval
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(delay):
+		}
+
+		results := make([][]api.LogEntry, len(followers))
+		errs := make([]error, len(followers))
+		runBounded(len(followers), concurrency, func(i int) {
+			results[i], errs[i] = followers[i].poll()
+		})
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		failed := false
+		var merged []agentLogEntry
+		for i, f := range followers {
+			if errs[i] != nil {
+				failed = true
+				fmt.Fprintln(os.Stderr, ui.RenderWarning(fmt.Sprintf("%s: %v (retrying in %s)", f.agentName, errs[i], backoff)))
+				continue
+			}
+			merged = append(merged, tagLogEntries(f.agentName, results[i])...)
+		}
+		if len(merged) > 0 {
+			sortAgentLogEntries(merged)
+			if err := emit(merged); err != nil {
+				return err
+			}
+		}
+
+		if failed {
+			delay = backoff
+			backoff *= 2
+			if backoff > maxFollowBackoff {
+				backoff = maxFollowBackoff
+			}
+		} else {
+			delay = interval
+			backoff = interval
+		}
+	}
+}
+
+// printLogEntry prints a single log entry with timestamp and level
+func printLogEntry(entry api.LogEntry) {
+	timestamp := ui.FormatLogTimestamp(entry.Timestamp)
+	levelPrefix := ui.FormatLogLevel(entry.LogLevel)
+	if levelPrefix != "" {
+		fmt.Printf("[%s] %s %s\n", timestamp, levelPrefix, entry.Log)
+	} else {
+		fmt.Printf("[%s] %s\n", timestamp, entry.Log)
+	}
+}
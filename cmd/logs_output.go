@@ -0,0 +1,377 @@
+package cmd
+
+import (
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Kavirubc/wso2-amp-cli/internal/api"
+	"github.com/Kavirubc/wso2-amp-cli/internal/ui"
+	"github.com/Kavirubc/wso2-amp-cli/internal/util"
+	"github.com/spf13/cobra"
+)
+
+// Log output formats accepted by --format
+const (
+	logFormatRaw      = "raw"
+	logFormatNDJSON   = "ndjson"
+	logFormatLogfmt   = "logfmt"
+	logFormatCSV      = "csv"
+	logFormatTemplate = "template"
+)
+
+// logOptions holds the local formatting and filtering flags shared by log commands
+type logOptions struct {
+	Format     string
+	Template   *template.Template
+	Grep       []*regexp.Regexp
+	Exclude    []*regexp.Regexp
+	OutputFile string
+}
+
+// addLogOutputFlags registers the formatting and local filter flags
+func addLogOutputFlags(cmd *cobra.Command) {
+	cmd.Flags().String("format", "", "Log format: raw, ndjson, logfmt, csv or template")
+	cmd.Flags().String("template", "", "Go template for each entry (implies --format template), e.g. '{{.Timestamp}} {{.Log}}'")
+	cmd.Flags().StringArray("grep", nil, "Only show entries whose message matches this regex (repeatable)")
+	cmd.Flags().StringArray("exclude", nil, "Hide entries whose message matches this regex (repeatable)")
+	cmd.Flags().String("output-file", "", "Write logs to a file instead of stdout (gzip-compressed if it ends in .gz)")
+}
+
+// addLogWindowFlags registers the time window flags used with --since
+func addLogWindowFlags(cmd *cobra.Command) {
+	cmd.Flags().String("until", "", "Show logs older than a duration (e.g., 30m, 2h)")
+	cmd.Flags().String("start", "", "Start time (RFC3339 format, e.g., 2025-01-20T13:00:00Z)")
+	cmd.Flags().String("end", "", "End time (RFC3339 format, e.g., 2025-01-20T14:00:00Z)")
+}
+
+// readLogOptions parses the flags registered by addLogOutputFlags
+func readLogOptions(cmd *cobra.Command) (*logOptions, error) {
+	format, _ := cmd.Flags().GetString("format")
+	tmpl, _ := cmd.Flags().GetString("template")
+	grep, _ := cmd.Flags().GetStringArray("grep")
+	exclude, _ := cmd.Flags().GetStringArray("exclude")
+	outputFile, _ := cmd.Flags().GetString("output-file")
+
+	opts := &logOptions{
+		Format:     strings.ToLower(strings.TrimSpace(format)),
+		OutputFile: outputFile,
+	}
+
+	if tmpl != "" {
+		if opts.Format != "" && opts.Format != logFormatTemplate {
+			return nil, fmt.Errorf("--template cannot be combined with --format %s", opts.Format)
+		}
+		t, err := template.New("log").Parse(tmpl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --template: %w", err)
+		}
+		opts.Format = logFormatTemplate
+		opts.Template = t
+	}
+
+	switch opts.Format {
+	case "", logFormatRaw, logFormatNDJSON, logFormatLogfmt, logFormatCSV:
+	case logFormatTemplate:
+		if opts.Template == nil {
+			return nil, fmt.Errorf("--format template requires --template")
+		}
+	default:
+		return nil, fmt.Errorf("invalid --format value %q: must be raw, ndjson, logfmt, csv or template", format)
+	}
+
+	for _, pattern := range grep {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --grep pattern %q: %w", pattern, err)
+		}
+		opts.Grep = append(opts.Grep, re)
+	}
+	for _, pattern := range exclude {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --exclude pattern %q: %w", pattern, err)
+		}
+		opts.Exclude = append(opts.Exclude, re)
+	}
+
+	return opts, nil
+}
+
+// resolveLogWindow turns --since/--until/--start/--end into RFC3339 start and end times.
+// Empty results mean the server default applies.
+func resolveLogWindow(since, until, start, end string) (string, string, error) {
+	if (since != "" || until != "") && (start != "" || end != "") {
+		return "", "", fmt.Errorf("--since/--until cannot be combined with --start/--end")
+	}
+
+	if start != "" || end != "" {
+		if start == "" || end == "" {
+			return "", "", fmt.Errorf("both --start and --end must be provided together")
+		}
+		startTime, err := time.Parse(time.RFC3339, start)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid --start time format. Use RFC3339 format (e.g., 2025-01-20T13:00:00Z)")
+		}
+		endTime, err := time.Parse(time.RFC3339, end)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid --end time format. Use RFC3339 format (e.g., 2025-01-20T14:00:00Z)")
+		}
+		if !endTime.After(startTime) {
+			return "", "", fmt.Errorf("--end must be after --start")
+		}
+		return start, end, nil
+	}
+
+	if since == "" && until == "" {
+		return "", "", nil
+	}
+
+	endTime := time.Now()
+	if until != "" {
+		t, err := util.ParseSinceDuration(until)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid --until value: %w", err)
+		}
+		endTime = t
+	}
+
+	var startTime time.Time
+	if since != "" {
+		t, err := util.ParseSinceDuration(since)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid --since value: %w", err)
+		}
+		startTime = t
+	} else {
+		// --until alone looks back one hour from the end time
+		startTime = endTime.Add(-1 * time.Hour)
+	}
+	if !endTime.After(startTime) {
+		return "", "", fmt.Errorf("--until must be shorter than --since")
+	}
+
+	return startTime.Format(time.RFC3339), endTime.Format(time.RFC3339), nil
+}
+
+// usesWriter reports whether entries go through a logWriter instead of the styled view
+func (o *logOptions) usesWriter() bool {
+	return o.Format != "" || o.OutputFile != ""
+}
+
+// matches applies --grep and --exclude to a log message
+func (o *logOptions) matches(entry api.LogEntry) bool {
+	for _, re := range o.Exclude {
+		if re.MatchString(entry.Log) {
+			return false
+		}
+	}
+	if len(o.Grep) == 0 {
+		return true
+	}
+	for _, re := range o.Grep {
+		if re.MatchString(entry.Log) {
+			return true
+		}
+	}
+	return false
+}
+
+// filter returns the entries that pass --grep and --exclude
+func (o *logOptions) filter(entries []agentLogEntry) []agentLogEntry {
+	if len(o.Grep) == 0 && len(o.Exclude) == 0 {
+		return entries
+	}
+	var kept []agentLogEntry
+	for _, entry := range entries {
+		if o.matches(entry.LogEntry) {
+			kept = append(kept, entry)
+		}
+	}
+	return kept
+}
+
+// filterEntries is filter for untagged entries
+func (o *logOptions) filterEntries(entries []api.LogEntry) []api.LogEntry {
+	if len(o.Grep) == 0 && len(o.Exclude) == 0 {
+		return entries
+	}
+	var kept []api.LogEntry
+	for _, entry := range entries {
+		if o.matches(entry) {
+			kept = append(kept, entry)
+		}
+	}
+	return kept
+}
+
+// logWriter writes log entries in one of the plain formats to stdout or a file
+type logWriter struct {
+	format    string
+	tmpl      *template.Template
+	out       io.Writer
+	csv       *csv.Writer
+	json      *json.Encoder
+	withAgent bool
+	closers   []io.Closer
+}
+
+// newLogWriter opens the destination for the given options. When no format is set,
+// files get ndjson for --output json and raw lines otherwise.
+func newLogWriter(opts *logOptions, output string, withAgent bool) (*logWriter, error) {
+	format := opts.Format
+	if format == "" {
+		format = logFormatRaw
+		if output == "json" {
+			format = logFormatNDJSON
+		}
+	}
+
+	w := &logWriter{format: format, tmpl: opts.Template, out: os.Stdout, withAgent: withAgent}
+
+	if opts.OutputFile != "" {
+		file, err := os.Create(opts.OutputFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create output file: %w", err)
+		}
+		w.out = file
+		w.closers = append(w.closers, file)
+		if strings.HasSuffix(opts.OutputFile, ".gz") {
+			gz := gzip.NewWriter(file)
+			w.out = gz
+			// Close the gzip stream before the file
+			w.closers = append([]io.Closer{gz}, w.closers...)
+		}
+	}
+
+	switch format {
+	case logFormatCSV:
+		w.csv = csv.NewWriter(w.out)
+		header := []string{"timestamp", "level", "log"}
+		if withAgent {
+			header = []string{"timestamp", "agent", "level", "log"}
+		}
+		if err := w.csv.Write(header); err != nil {
+			w.Close()
+			return nil, err
+		}
+	case logFormatNDJSON:
+		w.json = json.NewEncoder(w.out)
+	}
+
+	return w, nil
+}
+
+// writeLogs writes entries through a logWriter and reports where a file was written
+func writeLogs(opts *logOptions, output string, withAgent bool, entries []agentLogEntry) error {
+	w, err := newLogWriter(opts, output, withAgent)
+	if err != nil {
+		return err
+	}
+	if err := w.Write(entries); err != nil {
+		w.Close()
+		return fmt.Errorf("failed to write logs: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to write logs: %w", err)
+	}
+	if opts.OutputFile != "" {
+		fmt.Fprintln(os.Stderr, ui.RenderSuccess(fmt.Sprintf("Wrote %d log entries to %s", len(entries), opts.OutputFile)))
+	}
+	return nil
+}
+
+// Write writes entries in the configured format
+func (w *logWriter) Write(entries []agentLogEntry) error {
+	for _, entry := range entries {
+		var err error
+		switch w.format {
+		case logFormatNDJSON:
+			if w.withAgent {
+				err = w.json.Encode(entry)
+			} else {
+				err = w.json.Encode(entry.LogEntry)
+			}
+		case logFormatCSV:
+			record := []string{entry.Timestamp, entry.LogLevel, entry.Log}
+			if w.withAgent {
+				record = []string{entry.Timestamp, entry.Agent, entry.LogLevel, entry.Log}
+			}
+			err = w.csv.Write(record)
+		case logFormatLogfmt:
+			_, err = fmt.Fprintln(w.out, formatLogfmt(entry, w.withAgent))
+		case logFormatTemplate:
+			if err = w.tmpl.Execute(w.out, entry); err == nil {
+				_, err = fmt.Fprintln(w.out)
+			}
+		default:
+			_, err = fmt.Fprintln(w.out, formatRawLog(entry, w.withAgent))
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if w.csv != nil {
+		// Flush per batch so followed logs appear as they arrive
+		w.csv.Flush()
+		return w.csv.Error()
+	}
+	return nil
+}
+
+// Close flushes and closes the destination
+func (w *logWriter) Close() error {
+	if w.csv != nil {
+		w.csv.Flush()
+	}
+	var firstErr error
+	for _, c := range w.closers {
+		if err := c.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	w.closers = nil
+	return firstErr
+}
+
+// formatRawLog renders an entry as an unstyled line
+func formatRawLog(entry agentLogEntry, withAgent bool) string {
+	parts := []string{entry.Timestamp}
+	if withAgent {
+		parts = append(parts, entry.Agent)
+	}
+	if entry.LogLevel != "" {
+		parts = append(parts, strings.ToUpper(entry.LogLevel))
+	}
+	parts = append(parts, entry.Log)
+	return strings.Join(parts, " ")
+}
+
+// formatLogfmt renders an entry as logfmt key=value pairs
+func formatLogfmt(entry agentLogEntry, withAgent bool) string {
+	pairs := []string{"time=" + logfmtValue(entry.Timestamp)}
+	if withAgent {
+		pairs = append(pairs, "agent="+logfmtValue(entry.Agent))
+	}
+	pairs = append(pairs, "level="+logfmtValue(strings.ToLower(entry.LogLevel)))
+	pairs = append(pairs, "msg="+logfmtValue(entry.Log))
+	return strings.Join(pairs, " ")
+}
+
+// logfmtValue quotes a value when it contains spaces, quotes or '='
+func logfmtValue(value string) string {
+	if value == "" {
+		return `""`
+	}
+	if strings.ContainsAny(value, " \t\r\n\"=\\") {
+		return fmt.Sprintf("%q", value)
+	}
+	return value
+}
@@ -0,0 +1,293 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Kavirubc/wso2-amp-cli/internal/api"
+	"github.com/Kavirubc/wso2-amp-cli/internal/ui"
+)
+
+// Patterns masked when turning log messages into templates, applied in order
+var logTemplateMasks = []struct {
+	re          *regexp.Regexp
+	placeholder string
+}{
+	{regexp.MustCompile(`"(?:[^"\\]|\\.)*"`), `"<str>"`},
+	{regexp.MustCompile(`'(?:[^'\\]|\\.)*'`), `'<str>'`},
+	{regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`), "<uuid>"},
+	{regexp.MustCompile(`(?i)\b0x[0-9a-f]+\b`), "<hex>"},
+	{regexp.MustCompile(`(?i)\b[0-9a-f]*[a-f][0-9a-f]*\b`), "<hex>"},
+	{regexp.MustCompile(`\d+(\.\d+)?`), "<num>"},
+}
+
+// minHexLength is the shortest bare token treated as a hex id
+const minHexLength = 8
+
+// summaryBucketSizes are the candidate histogram bucket widths
+var summaryBucketSizes = []time.Duration{
+	time.Minute, 5 * time.Minute, 10 * time.Minute, 15 * time.Minute, 30 * time.Minute,
+	time.Hour, 3 * time.Hour, 6 * time.Hour, 12 * time.Hour, 24 * time.Hour,
+}
+
+// maxSummaryBuckets caps the number of histogram rows
+const maxSummaryBuckets = 12
+
+// Paging of the window read by --summarize
+const (
+	summaryPageSize     = 1000
+	maxSummaryEntries   = 50000
+	defaultSummaryRange = time.Hour
+)
+
+// logTemplate is a group of log messages that share a normalised form
+type logTemplate struct {
+	Template  string    `json:"template"`
+	Level     string    `json:"level"`
+	Count     int       `json:"count"`
+	FirstSeen time.Time `json:"firstSeen"`
+	LastSeen  time.Time `json:"lastSeen"`
+	Sample    string    `json:"sample"`
+}
+
+// logHistogramBucket counts entries per level in one time bucket
+type logHistogramBucket struct {
+	Start  time.Time      `json:"start"`
+	Levels map[string]int `json:"levels"`
+	Total  int            `json:"total"`
+}
+
+// logSummary is the result of --summarize
+type logSummary struct {
+	TotalEntries int                  `json:"totalEntries"`
+	Templates    []logTemplate        `json:"templates"`
+	BucketSize   string               `json:"bucketSize"`
+	Histogram    []logHistogramBucket `json:"histogram"`
+}
+
+// fetchSummaryEntries reads every entry of the window oldest first, paging
+// forward with a startTime cursor. When it stops early, at maxSummaryEntries
+// or at a timestamp with more than a page of entries, it returns how far the
+// entries reach.
+func fetchSummaryEntries(client *api.Client, org, project, agentName string, req api.RuntimeLogRequest, start, end time.Time) ([]api.LogEntry, time.Time, error) {
+	req.Limit = summaryPageSize
+	f := newLogFollower(client, org, project, agentName, req, start)
+	f.end = end
+
+	var entries []api.LogEntry
+	for {
+		fresh, err := f.poll()
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		entries = append(entries, fresh...)
+		if f.lastCount < summaryPageSize {
+			return entries, time.Time{}, nil
+		}
+		if len(entries) >= maxSummaryEntries || len(fresh) == 0 {
+			return entries, f.cursor, nil
+		}
+	}
+}
+
+// normalizeLogMessage masks quoted strings, UUIDs, hex ids and numbers
+func normalizeLogMessage(message string) string {
+	result := strings.TrimSpace(message)
+	for _, mask := range logTemplateMasks {
+		result = mask.re.ReplaceAllStringFunc(result, func(match string) string {
+			// Bare hex ids must be long and contain a digit, so words like "facade" survive
+			if mask.placeholder == "<hex>" && !strings.HasPrefix(strings.ToLower(match), "0x") &&
+				(len(match) < minHexLength || !strings.ContainsAny(match, "0123456789")) {
+				return match
+			}
+			return mask.placeholder
+		})
+	}
+	return result
+}
+
+// summarizeLogs groups entries into templates and builds a level histogram.
+// windowStart and windowEnd may be zero, in which case the entries' own range is used.
+func summarizeLogs(entries []api.LogEntry, windowStart, windowEnd time.Time) logSummary {
+	summary := logSummary{TotalEntries: len(entries), Templates: []logTemplate{}, Histogram: []logHistogramBucket{}}
+	if len(entries) == 0 {
+		return summary
+	}
+
+	// Group by level and template
+	groups := make(map[string]*logTemplate)
+	var order []string
+	var minTime, maxTime time.Time
+	for _, entry := range entries {
+		t := parseLogTime(entry.Timestamp, time.Time{})
+		level := normalizeLogLevel(entry.LogLevel)
+		template := normalizeLogMessage(entry.Log)
+		key := level + "\x00" + template
+
+		group, ok := groups[key]
+		if !ok {
+			group = &logTemplate{Template: template, Level: level, FirstSeen: t, LastSeen: t, Sample: entry.Log}
+			groups[key] = group
+			order = append(order, key)
+		}
+		group.Count++
+		if t.Before(group.FirstSeen) {
+			group.FirstSeen = t
+		}
+		if t.After(group.LastSeen) {
+			group.LastSeen = t
+			group.Sample = entry.Log
+		}
+
+		if !t.IsZero() {
+			if minTime.IsZero() || t.Before(minTime) {
+				minTime = t
+			}
+			if t.After(maxTime) {
+				maxTime = t
+			}
+		}
+	}
+	for _, key := range order {
+		summary.Templates = append(summary.Templates, *groups[key])
+	}
+	sort.SliceStable(summary.Templates, func(i, j int) bool {
+		return summary.Templates[i].Count > summary.Templates[j].Count
+	})
+
+	// Histogram over the requested window, or the range of the entries
+	if windowStart.IsZero() || windowEnd.IsZero() {
+		windowStart, windowEnd = minTime, maxTime
+	}
+	if windowStart.IsZero() {
+		return summary
+	}
+	bucketSize := summaryBucketSizes[len(summaryBucketSizes)-1]
+	for _, size := range summaryBucketSizes {
+		if windowEnd.Sub(windowStart.Truncate(size))/size < maxSummaryBuckets {
+			bucketSize = size
+			break
+		}
+	}
+	summary.BucketSize = bucketSize.String()
+
+	first := windowStart.Truncate(bucketSize)
+	count := int(windowEnd.Sub(first)/bucketSize) + 1
+	for i := 0; i < count; i++ {
+		summary.Histogram = append(summary.Histogram, logHistogramBucket{
+			Start:  first.Add(time.Duration(i) * bucketSize),
+			Levels: make(map[string]int),
+		})
+	}
+	for _, entry := range entries {
+		t := parseLogTime(entry.Timestamp, time.Time{})
+		if t.IsZero() || t.Before(first) {
+			continue
+		}
+		i := int(t.Sub(first) / bucketSize)
+		if i >= len(summary.Histogram) {
+			continue
+		}
+		summary.Histogram[i].Levels[normalizeLogLevel(entry.LogLevel)]++
+		summary.Histogram[i].Total++
+	}
+
+	return summary
+}
+
+// normalizeLogLevel upper-cases a level and folds WARNING into WARN
+func normalizeLogLevel(level string) string {
+	level = strings.ToUpper(strings.TrimSpace(level))
+	switch level {
+	case "WARNING":
+		return "WARN"
+	case "":
+		return "-"
+	}
+	return level
+}
+
+// printLogSummary renders the summary as tables
+func printLogSummary(title string, summary logSummary, top int, output string) error {
+	if output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		encoder.SetEscapeHTML(false)
+		return encoder.Encode(summary)
+	}
+
+	fmt.Println(ui.TitleStyle.Render(title))
+
+	if summary.TotalEntries == 0 {
+		fmt.Println(ui.RenderWarning("No logs found for the specified criteria."))
+		return nil
+	}
+
+	// Top templates
+	templates := summary.Templates
+	if top > 0 && len(templates) > top {
+		templates = templates[:top]
+	}
+	headers := []string{"COUNT", "LEVEL", "FIRST SEEN", "LAST SEEN", "TEMPLATE", "SAMPLE"}
+	rows := make([][]string, len(templates))
+	for i, t := range templates {
+		rows[i] = []string{
+			fmt.Sprintf("%d", t.Count),
+			ui.FormatLogLevel(t.Level),
+			formatSummaryTime(t.FirstSeen),
+			formatSummaryTime(t.LastSeen),
+			ui.TruncateString(t.Template, 50),
+			ui.TruncateString(t.Sample, 40),
+		}
+	}
+	fmt.Println(ui.RenderTableWithTitle(fmt.Sprintf("Top %d of %d templates", len(templates), len(summary.Templates)), headers, rows))
+
+	// Level histogram
+	if len(summary.Histogram) > 0 {
+		maxTotal := 0
+		for _, b := range summary.Histogram {
+			if b.Total > maxTotal {
+				maxTotal = b.Total
+			}
+		}
+		headers := []string{"TIME", "ERROR", "WARN", "INFO", "DEBUG", "TOTAL", ""}
+		rows := make([][]string, len(summary.Histogram))
+		for i, b := range summary.Histogram {
+			bar := ""
+			if maxTotal > 0 {
+				bar = strings.Repeat("█", (b.Total*20+maxTotal-1)/maxTotal)
+			}
+			if b.Levels["ERROR"] > 0 {
+				bar = ui.LogErrorStyle.Render(bar)
+			}
+			rows[i] = []string{
+				formatSummaryTime(b.Start),
+				fmt.Sprintf("%d", b.Levels["ERROR"]),
+				fmt.Sprintf("%d", b.Levels["WARN"]),
+				fmt.Sprintf("%d", b.Levels["INFO"]),
+				fmt.Sprintf("%d", b.Levels["DEBUG"]),
+				fmt.Sprintf("%d", b.Total),
+				bar,
+			}
+		}
+		fmt.Println()
+		fmt.Println(ui.RenderTableWithTitle(fmt.Sprintf("Levels per %s", summary.BucketSize), headers, rows))
+	}
+
+	fmt.Println()
+	fmt.Println(ui.MutedStyle.Render(fmt.Sprintf("Summarized %d log entries into %d templates", summary.TotalEntries, len(summary.Templates))))
+	return nil
+}
+
+// formatSummaryTime formats a time for summary tables
+func formatSummaryTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04:05")
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Kavirubc/wso2-amp-cli/internal/api"
+	"github.com/Kavirubc/wso2-amp-cli/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// validateTUIFlags rejects output options that make no sense in the full-screen viewer
+func validateTUIFlags(opts *logOptions, output string) error {
+	if opts.usesWriter() || output == "json" {
+		return fmt.Errorf("--tui cannot be combined with --format, --template, --output-file or --output json")
+	}
+	return nil
+}
+
+// toLogLines converts log entries for the viewer, oldest first
+func toLogLines(entries []agentLogEntry) []ui.LogLine {
+	sorted := append([]agentLogEntry(nil), entries...)
+	sortAgentLogEntries(sorted)
+	lines := make([]ui.LogLine, len(sorted))
+	for i, entry := range sorted {
+		lines[i] = ui.LogLine{
+			Time:    parseLogTime(entry.Timestamp, time.Time{}),
+			Source:  entry.Agent,
+			Level:   entry.LogLevel,
+			Message: entry.Log,
+		}
+	}
+	return lines
+}
+
+// runLogViewer opens the full-screen log viewer
+func runLogViewer(title string, lines []ui.LogLine, poll ui.LogPollFunc, interval time.Duration) error {
+	if interval <= 0 {
+		interval = defaultFollowInterval
+	}
+	model := ui.NewLogViewerModel(title, lines, poll, interval)
+	if _, err := tea.NewProgram(model, tea.WithAltScreen()).Run(); err != nil {
+		return fmt.Errorf("log viewer failed: %w", err)
+	}
+	return nil
+}
+
+// runAgentLogsTUI shows runtime logs in the viewer, following new entries when follow is set
+func runAgentLogsTUI(client *api.Client, org, project, agentName, envName string, req api.RuntimeLogRequest, initial []api.LogEntry, follow bool, interval time.Duration, opts *logOptions) error {
+	recent := append([]api.LogEntry(nil), initial...)
+	sortLogEntries(recent)
+
+	var poll ui.LogPollFunc
+	if follow {
+		var start time.Time
+		if req.StartTime != "" {
+			if t, err := time.Parse(time.RFC3339, req.StartTime); err == nil {
+				start = t
+			}
+		} else if len(recent) == 0 {
+			start = time.Now()
+		}
+		follower := newLogFollower(client, org, project, agentName, req, start)
+		follower.prime(recent)
+		poll = func() ([]ui.LogLine, error) {
+			entries, err := follower.poll()
+			if err != nil {
+				return nil, err
+			}
+			return toLogLines(opts.filter(tagLogEntries("", entries))), nil
+		}
+	}
+
+	title := fmt.Sprintf("%s Runtime Logs: %s (%s)", ui.IconAgent, agentName, envName)
+	lines := toLogLines(tagLogEntries("", opts.filterEntries(recent)))
+	return runLogViewer(title, lines, poll, interval)
+}
@@ -0,0 +1,262 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/Kavirubc/wso2-amp-cli/internal/api"
+	"github.com/Kavirubc/wso2-amp-cli/internal/config"
+	"github.com/Kavirubc/wso2-amp-cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// Defaults for metrics serve
+const (
+	defaultMetricsListen   = ":9464"
+	defaultMetricsRefresh  = 30 * time.Second
+	defaultMetricsLookback = 5 * time.Minute
+)
+
+// metricsExporter keeps the latest OpenMetrics snapshot of a set of agents
+type metricsExporter struct {
+	client      *api.Client
+	org         string
+	project     string
+	envName     string
+	agents      []string // empty means every agent in the project
+	lookback    time.Duration
+	concurrency int
+
+	mu       sync.RWMutex
+	snapshot []byte
+	lastErr  error
+}
+
+// refresh fetches metrics for every agent and rebuilds the snapshot
+func (e *metricsExporter) refresh() error {
+	agents := e.agents
+	if len(agents) == 0 {
+		names, err := listAllAgentNames(e.client, e.org, e.project)
+		if err != nil {
+			e.setError(fmt.Errorf("failed to list agents: %w", err))
+			return e.lastError()
+		}
+		agents = names
+	}
+
+	now := time.Now()
+	req := api.MetricsFilterRequest{
+		EnvironmentName: e.envName,
+		StartTime:       now.Add(-e.lookback).Format(time.RFC3339),
+		EndTime:         now.Format(time.RFC3339),
+	}
+	results := make([]*api.MetricsResponse, len(agents))
+	errs := make([]error, len(agents))
+	runBounded(len(agents), e.concurrency, func(i int) {
+		results[i], errs[i] = e.client.GetAgentMetrics(e.org, e.project, agents[i], req)
+	})
+
+	var samples []agentMetricsSample
+	var up bytes.Buffer
+	up.WriteString("# TYPE amp_agent_metrics_up gauge\n")
+	up.WriteString("# HELP amp_agent_metrics_up Whether the last refresh of the agent's metrics succeeded.\n")
+	var failed []string
+	for i, name := range agents {
+		labels := metricLabels{Agent: name, Project: e.project, Environment: e.envName}
+		value := "1"
+		if errs[i] != nil {
+			value = "0"
+			failed = append(failed, name)
+		} else {
+			samples = append(samples, agentMetricsSample{Labels: labels, Metrics: results[i]})
+		}
+		fmt.Fprintf(&up, "amp_agent_metrics_up%s %s\n", formatOpenMetricsLabels(labels), value)
+	}
+
+	var body bytes.Buffer
+	body.Write(up.Bytes())
+	if err := writeOpenMetrics(&body, samples, true); err != nil {
+		e.setError(err)
+		return err
+	}
+
+	e.mu.Lock()
+	e.snapshot = body.Bytes()
+	e.lastErr = nil
+	if len(failed) > 0 {
+		sort.Strings(failed)
+		e.lastErr = fmt.Errorf("failed to get metrics for %d agent(s): %v", len(failed), failed)
+	}
+	e.mu.Unlock()
+	return e.lastError()
+}
+
+func (e *metricsExporter) setError(err error) {
+	e.mu.Lock()
+	e.lastErr = err
+	e.mu.Unlock()
+}
+
+func (e *metricsExporter) lastError() error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.lastErr
+}
+
+// ServeHTTP serves the latest snapshot
+func (e *metricsExporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e.mu.RLock()
+	snapshot := e.snapshot
+	e.mu.RUnlock()
+	if snapshot == nil {
+		http.Error(w, "metrics not collected yet", http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", openMetricsContentType)
+	_, _ = w.Write(snapshot)
+}
+
+var metricsCmd = &cobra.Command{
+	Use:   "metrics",
+	Short: "Analyse and export agent metrics across a project",
+	Long:  "Analyse agent resource metrics across a project and export them to external monitoring systems.",
+}
+
+var metricsServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Expose agent metrics as a Prometheus scrape endpoint",
+	Long: `Serve CPU and memory metrics for a set of agents in the OpenMetrics text
+format at /metrics. Metrics are refreshed in the background every
+--refresh, and each scrape returns the latest value of every series with
+agent, project and environment labels.
+
+amp_agent_metrics_up reports whether the last refresh of each agent
+succeeded. Without --agent, every agent in the project is exported.
+
+Examples:
+  amp metrics serve --env production
+  amp metrics serve --env production --listen :9464 --agent myagent --agent other
+  amp metrics serve --env dev --refresh 1m`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flags
+		org, _ := cmd.Flags().GetString("org")
+		project, _ := cmd.Flags().GetString("project")
+		envName, _ := cmd.Flags().GetString("env")
+		agents, _ := cmd.Flags().GetStringArray("agent")
+		listen, _ := cmd.Flags().GetString("listen")
+		refresh, _ := cmd.Flags().GetDuration("refresh")
+		lookback, _ := cmd.Flags().GetDuration("lookback")
+		concurrency, _ := cmd.Flags().GetInt("concurrency")
+
+		// Use defaults from config if not provided
+		if org == "" {
+			org = config.GetDefaultOrg()
+		}
+		if project == "" {
+			project = config.GetDefaultProject()
+		}
+
+		// Validate required fields
+		if org == "" {
+			return fmt.Errorf("organization is required. Use --org flag or set default with: amp config set default_org <name>")
+		}
+		if project == "" {
+			return fmt.Errorf("project is required. Use --project flag or set default with: amp config set default_project <name>")
+		}
+		if envName == "" {
+			return fmt.Errorf("environment name is required. Use --env flag")
+		}
+		if refresh <= 0 {
+			return fmt.Errorf("--refresh must be positive")
+		}
+		if lookback <= 0 {
+			return fmt.Errorf("--lookback must be positive")
+		}
+
+		exporter := &metricsExporter{
+			client: api.NewClient(
+				config.GetAPIURL(),
+				config.GetAPIKeyHeader(),
+				config.GetAPIKeyValue(),
+			),
+			org:         org,
+			project:     project,
+			envName:     envName,
+			agents:      agents,
+			lookback:    lookback,
+			concurrency: concurrency,
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		// Collect once before accepting scrapes
+		if err := exporter.refresh(); err != nil {
+			fmt.Fprintln(os.Stderr, ui.RenderWarning(err.Error()))
+		}
+		go func() {
+			ticker := time.NewTicker(refresh)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if err := exporter.refresh(); err != nil {
+						fmt.Fprintln(os.Stderr, ui.RenderWarning(err.Error()))
+					}
+				}
+			}
+		}()
+
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", exporter)
+		mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/" {
+				http.NotFound(w, r)
+				return
+			}
+			fmt.Fprintln(w, "amp metrics exporter: scrape /metrics")
+		})
+		server := &http.Server{Addr: listen, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- server.ListenAndServe()
+		}()
+		fmt.Fprintln(os.Stderr, ui.RenderInfo(fmt.Sprintf("Serving metrics for %s (%s) on %s/metrics, refreshing every %s. Press Ctrl+C to stop.", project, envName, listen, refresh)))
+
+		select {
+		case err := <-errCh:
+			if !errors.Is(err, http.ErrServerClosed) {
+				return fmt.Errorf("metrics server failed: %w", err)
+			}
+			return nil
+		case <-ctx.Done():
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			return server.Shutdown(shutdownCtx)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(metricsCmd)
+	metricsCmd.AddCommand(metricsServeCmd)
+
+	metricsServeCmd.Flags().StringP("env", "e", "", "Environment name (required)")
+	metricsServeCmd.Flags().StringArrayP("agent", "a", nil, "Agent to export (repeatable, default: all agents in the project)")
+	metricsServeCmd.Flags().String("listen", defaultMetricsListen, "Address to listen on")
+	metricsServeCmd.Flags().Duration("refresh", defaultMetricsRefresh, "Interval between metric refreshes")
+	metricsServeCmd.Flags().Duration("lookback", defaultMetricsLookback, "Window fetched on each refresh")
+	metricsServeCmd.Flags().Int("concurrency", defaultLogsConcurrency, "Number of agents queried at once")
+}
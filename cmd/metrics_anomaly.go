@@ -0,0 +1,489 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Kavirubc/wso2-amp-cli/internal/api"
+	"github.com/Kavirubc/wso2-amp-cli/internal/config"
+	"github.com/Kavirubc/wso2-amp-cli/internal/ui"
+	"github.com/Kavirubc/wso2-amp-cli/internal/util"
+	"github.com/spf13/cobra"
+)
+
+// Anomaly detectors
+const (
+	anomalySpike      = "spike"
+	anomalyGrowth     = "monotonic-growth"
+	anomalySaturation = "limit-saturation"
+)
+
+// Anomaly severities
+const (
+	severityWarning  = "warning"
+	severityCritical = "critical"
+)
+
+// minSpikeStdFraction is the smallest standard deviation, relative to the
+// window mean, used for z-scores. On a flat series a step must then reach
+// ZThreshold times 5% of the mean, 15% by default, before it counts as a spike.
+const minSpikeStdFraction = 0.05
+
+// anomalyOptions tunes the detectors
+type anomalyOptions struct {
+	Window              int     // points in the rolling z-score window
+	ZThreshold          float64 // |z| at or above which a point is a spike
+	SaturationThreshold float64 // fraction of the limit counted as saturated
+	SaturationPoints    int     // consecutive saturated points to report
+	GrowthRatio         float64 // fraction of rising steps for monotonic growth
+	MinGrowth           float64 // growth relative to the first value
+}
+
+// defaultAnomalyOptions returns the detector defaults
+func defaultAnomalyOptions() anomalyOptions {
+	return anomalyOptions{
+		Window:              10,
+		ZThreshold:          3,
+		SaturationThreshold: 0.9,
+		SaturationPoints:    5,
+		GrowthRatio:         0.8,
+		MinGrowth:           0.1,
+	}
+}
+
+// metricAnomaly is one finding of a detector
+type metricAnomaly struct {
+	Agent       string    `json:"agent"`
+	Environment string    `json:"environment"`
+	Resource    string    `json:"resource"`
+	Detector    string    `json:"detector"`
+	Severity    string    `json:"severity"`
+	Start       time.Time `json:"start"`
+	End         time.Time `json:"end"`
+	Message     string    `json:"message"`
+}
+
+// anomalyReport is the JSON shape of anomaly detection
+type anomalyReport struct {
+	Project     string            `json:"project"`
+	Environment string            `json:"environment"`
+	StartTime   string            `json:"startTime"`
+	EndTime     string            `json:"endTime"`
+	Agents      []string          `json:"agents"`
+	Anomalies   []metricAnomaly   `json:"anomalies"`
+	Errors      map[string]string `json:"errors,omitempty"`
+}
+
+// detectAnomalies runs every detector over the usage series of an agent
+func detectAnomalies(agent, envName string, metrics *api.MetricsResponse, opts anomalyOptions) []metricAnomaly {
+	var found []metricAnomaly
+	for _, series := range agentMetricSeries(metrics) {
+		if len(series.Usage) == 0 {
+			continue
+		}
+		base := metricAnomaly{Agent: agent, Environment: envName, Resource: strings.ToLower(series.Name)}
+		found = append(found, detectSpikes(base, series, opts)...)
+		if a, ok := detectGrowth(base, series, opts); ok {
+			found = append(found, a)
+		}
+		found = append(found, detectSaturation(base, series, opts)...)
+	}
+	return found
+}
+
+// detectSpikes flags runs of points whose rolling z-score exceeds the threshold
+func detectSpikes(base metricAnomaly, series metricSeries, opts anomalyOptions) []metricAnomaly {
+	values := metricValues(series.Usage)
+	var found []metricAnomaly
+	runStart, peakZ, peak := -1, 0.0, 0.0
+	flush := func(end int) {
+		if runStart < 0 {
+			return
+		}
+		a := base
+		a.Detector = anomalySpike
+		a.Severity = severityWarning
+		if math.Abs(peakZ) >= opts.ZThreshold*5/3 {
+			a.Severity = severityCritical
+		}
+		a.Start = pointTime(series.Usage[runStart])
+		a.End = pointTime(series.Usage[end])
+		direction := "spike"
+		if peakZ < 0 {
+			direction = "drop"
+		}
+		a.Message = fmt.Sprintf("%s %s to %s (z-score %.1f)", base.Resource, direction, series.Format(peak), peakZ)
+		found = append(found, a)
+		runStart = -1
+	}
+
+	for i := opts.Window; i < len(values); i++ {
+		mean, std := meanStd(values[i-opts.Window : i])
+		// Ignore jitter on nearly flat series
+		std = math.Max(std, math.Abs(mean)*minSpikeStdFraction)
+		if std == 0 {
+			flush(i - 1)
+			continue
+		}
+		z := (values[i] - mean) / std
+		if math.Abs(z) < opts.ZThreshold {
+			flush(i - 1)
+			continue
+		}
+		if runStart < 0 {
+			runStart, peakZ, peak = i, z, values[i]
+		} else if math.Abs(z) > math.Abs(peakZ) {
+			peakZ, peak = z, values[i]
+		}
+	}
+	flush(len(values) - 1)
+	return found
+}
+
+// detectGrowth flags a series that rises almost every step, such as a memory leak
+func detectGrowth(base metricAnomaly, series metricSeries, opts anomalyOptions) (metricAnomaly, bool) {
+	values := metricValues(series.Usage)
+	if len(values) < opts.Window {
+		return metricAnomaly{}, false
+	}
+	rising, moving := 0, 0
+	for i := 1; i < len(values); i++ {
+		if values[i] != values[i-1] {
+			moving++
+			if values[i] > values[i-1] {
+				rising++
+			}
+		}
+	}
+	first, last := values[0], values[len(values)-1]
+	if moving == 0 || float64(rising)/float64(moving) < opts.GrowthRatio || first <= 0 || (last-first)/first < opts.MinGrowth {
+		return metricAnomaly{}, false
+	}
+
+	a := base
+	a.Detector = anomalyGrowth
+	a.Severity = severityWarning
+	a.Start = pointTime(series.Usage[0])
+	a.End = pointTime(series.Usage[len(series.Usage)-1])
+	a.Message = fmt.Sprintf("%s grew from %s to %s (+%.0f%%), rising in %d of %d steps",
+		base.Resource, series.Format(first), series.Format(last), (last-first)/first*100, rising, moving)
+
+	// Project when the limit will be reached at the current rate
+	if elapsed := a.End.Sub(a.Start); elapsed > 0 && len(series.Limits) > 0 {
+		limit := series.Limits[len(series.Limits)-1].Value
+		if limit > last {
+			rate := (last - first) / elapsed.Hours()
+			eta := time.Duration((limit - last) / rate * float64(time.Hour))
+			a.Message += fmt.Sprintf("; reaches the %s limit in about %s", series.Format(limit), formatApproxDuration(eta))
+			if eta < 24*time.Hour {
+				a.Severity = severityCritical
+			}
+		}
+	}
+	return a, true
+}
+
+// detectSaturation flags runs of points at or above the threshold of the limit
+func detectSaturation(base metricAnomaly, series metricSeries, opts anomalyOptions) []metricAnomaly {
+	if len(series.Limits) == 0 {
+		return nil
+	}
+	var found []metricAnomaly
+	runStart, peakRatio := -1, 0.0
+	flush := func(end int) {
+		if runStart >= 0 && end-runStart+1 >= opts.SaturationPoints {
+			a := base
+			a.Detector = anomalySaturation
+			a.Severity = severityWarning
+			if peakRatio >= 0.98 {
+				a.Severity = severityCritical
+			}
+			a.Start = pointTime(series.Usage[runStart])
+			a.End = pointTime(series.Usage[end])
+			a.Message = fmt.Sprintf("%s at or above %.0f%% of limit for %d points (peak %.0f%%)",
+				base.Resource, opts.SaturationThreshold*100, end-runStart+1, peakRatio*100)
+			found = append(found, a)
+		}
+		runStart, peakRatio = -1, 0
+	}
+
+	for i, p := range series.Usage {
+		limit := limitAt(series.Limits, i, len(series.Usage))
+		if limit <= 0 || p.Value < limit*opts.SaturationThreshold {
+			flush(i - 1)
+			continue
+		}
+		if runStart < 0 {
+			runStart = i
+		}
+		peakRatio = math.Max(peakRatio, p.Value/limit)
+	}
+	flush(len(series.Usage) - 1)
+	return found
+}
+
+// limitAt returns the limit matching usage point i, or the latest limit when the series are not aligned
+func limitAt(limits []api.MetricDataPoint, i, usageLen int) float64 {
+	if len(limits) == usageLen {
+		return limits[i].Value
+	}
+	return limits[len(limits)-1].Value
+}
+
+// meanStd returns the mean and population standard deviation
+func meanStd(values []float64) (float64, float64) {
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+	variance := 0.0
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(variance / float64(len(values)))
+}
+
+// pointTime parses a data point timestamp, returning the zero time on failure
+func pointTime(p api.MetricDataPoint) time.Time {
+	t, _ := parseMetricTimestamp(p.Timestamp)
+	return t
+}
+
+// formatApproxDuration formats a duration in the largest sensible unit
+func formatApproxDuration(d time.Duration) string {
+	switch {
+	case d >= 48*time.Hour:
+		return fmt.Sprintf("%.0fd", d.Hours()/24)
+	case d >= time.Hour:
+		return fmt.Sprintf("%.0fh", d.Hours())
+	default:
+		return fmt.Sprintf("%.0fm", math.Max(d.Minutes(), 1))
+	}
+}
+
+// sortAnomalies orders findings by severity, then agent and start time
+func sortAnomalies(anomalies []metricAnomaly) {
+	sort.SliceStable(anomalies, func(i, j int) bool {
+		a, b := anomalies[i], anomalies[j]
+		if a.Severity != b.Severity {
+			return a.Severity == severityCritical
+		}
+		if a.Agent != b.Agent {
+			return a.Agent < b.Agent
+		}
+		return a.Start.Before(b.Start)
+	})
+}
+
+// printAnomalyReport renders the findings
+func printAnomalyReport(title string, report anomalyReport) {
+	fmt.Println(ui.TitleStyle.Render(title))
+	fmt.Println()
+	fmt.Printf("  %s  %s - %s\n", ui.KeyStyle.Render("Time Range:"), ui.FormatMetricTimestamp(report.StartTime), ui.FormatMetricTimestamp(report.EndTime))
+	fmt.Printf("  %s  %d\n", ui.KeyStyle.Render("Agents Analysed:"), len(report.Agents))
+	fmt.Println()
+
+	if len(report.Anomalies) == 0 {
+		fmt.Println(ui.RenderSuccess("No anomalies detected."))
+	} else {
+		headers := []string{"SEVERITY", "AGENT", "ENV", "RESOURCE", "DETECTOR", "FROM", "TO", "DETAILS"}
+		rows := make([][]string, len(report.Anomalies))
+		for i, a := range report.Anomalies {
+			rows[i] = []string{
+				formatSeverity(a.Severity),
+				a.Agent,
+				a.Environment,
+				a.Resource,
+				a.Detector,
+				formatSummaryTime(a.Start),
+				formatSummaryTime(a.End),
+				a.Message,
+			}
+		}
+		fmt.Println(ui.RenderTable(headers, rows))
+	}
+
+	if len(report.Errors) > 0 {
+		fmt.Println()
+		names := make([]string, 0, len(report.Errors))
+		for name := range report.Errors {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Println(ui.RenderWarning(fmt.Sprintf("%s: %s", name, report.Errors[name])))
+		}
+	}
+}
+
+// formatSeverity colours a severity
+func formatSeverity(severity string) string {
+	if severity == severityCritical {
+		return ui.ErrorStyle.Render(severity)
+	}
+	return ui.WarningStyle.Render(severity)
+}
+
+// writeAnomalyReport prints the report as JSON or tables
+func writeAnomalyReport(title string, report anomalyReport, output string) error {
+	if report.Anomalies == nil {
+		report.Anomalies = []metricAnomaly{}
+	}
+	if output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+	printAnomalyReport(title, report)
+	return nil
+}
+
+// readAnomalyOptions reads the detector flags
+func readAnomalyOptions(cmd *cobra.Command) (anomalyOptions, error) {
+	opts := defaultAnomalyOptions()
+	opts.Window, _ = cmd.Flags().GetInt("anomaly-window")
+	opts.ZThreshold, _ = cmd.Flags().GetFloat64("z-threshold")
+	saturation, _ := cmd.Flags().GetFloat64("saturation-threshold")
+	if opts.Window < 2 {
+		return opts, fmt.Errorf("--anomaly-window must be at least 2")
+	}
+	if opts.ZThreshold <= 0 {
+		return opts, fmt.Errorf("--z-threshold must be positive")
+	}
+	if saturation <= 0 || saturation > 100 {
+		return opts, fmt.Errorf("--saturation-threshold must be between 0 and 100")
+	}
+	opts.SaturationThreshold = saturation / 100
+	return opts, nil
+}
+
+// addAnomalyFlags registers the detector flags
+func addAnomalyFlags(cmd *cobra.Command) {
+	defaults := defaultAnomalyOptions()
+	cmd.Flags().Int("anomaly-window", defaults.Window, "Points in the rolling z-score window")
+	cmd.Flags().Float64("z-threshold", defaults.ZThreshold, "Z-score at or above which a point is a spike")
+	cmd.Flags().Float64("saturation-threshold", defaults.SaturationThreshold*100, "Percent of the limit counted as saturated")
+}
+
+var metricsAnomaliesCmd = &cobra.Command{
+	Use:   "anomalies",
+	Short: "Detect metric anomalies across all agents in a project",
+	Long: `Run anomaly detectors over the CPU and memory usage of every agent in a
+project:
+
+  spike             a rolling z-score above --z-threshold
+  monotonic-growth  usage rising almost every step, such as a memory leak
+  limit-saturation  usage above --saturation-threshold percent of the limit
+                    for several consecutive points
+
+Examples:
+  amp metrics anomalies --env production
+  amp metrics anomalies --env production --since 24h
+  amp metrics anomalies --env dev --z-threshold 4 --output json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flags
+		org, _ := cmd.Flags().GetString("org")
+		project, _ := cmd.Flags().GetString("project")
+		envName, _ := cmd.Flags().GetString("env")
+		agentNames, _ := cmd.Flags().GetStringArray("agent")
+		since, _ := cmd.Flags().GetString("since")
+		concurrency, _ := cmd.Flags().GetInt("concurrency")
+		output, _ := cmd.Flags().GetString("output")
+
+		// Use defaults from config if not provided
+		if org == "" {
+			org = config.GetDefaultOrg()
+		}
+		if project == "" {
+			project = config.GetDefaultProject()
+		}
+
+		// Validate required fields
+		if org == "" {
+			return fmt.Errorf("organization is required. Use --org flag or set default with: amp config set default_org <name>")
+		}
+		if project == "" {
+			return fmt.Errorf("project is required. Use --project flag or set default with: amp config set default_project <name>")
+		}
+		if envName == "" {
+			return fmt.Errorf("environment name is required. Use --env flag")
+		}
+
+		opts, err := readAnomalyOptions(cmd)
+		if err != nil {
+			return err
+		}
+		start, err := util.ParseSinceDuration(since)
+		if err != nil {
+			return fmt.Errorf("invalid --since value: %w", err)
+		}
+		req := api.MetricsFilterRequest{
+			EnvironmentName: envName,
+			StartTime:       start.Format(time.RFC3339),
+			EndTime:         time.Now().Format(time.RFC3339),
+		}
+
+		// Create API client
+		client := api.NewClient(
+			config.GetAPIURL(),
+			config.GetAPIKeyHeader(),
+			config.GetAPIKeyValue(),
+		)
+
+		if len(agentNames) == 0 {
+			agentNames, err = listAllAgentNames(client, org, project)
+			if err != nil {
+				return fmt.Errorf("failed to list agents: %w", err)
+			}
+		}
+
+		found := make([][]metricAnomaly, len(agentNames))
+		errs := make([]error, len(agentNames))
+		runBounded(len(agentNames), concurrency, func(i int) {
+			metrics, err := client.GetAgentMetrics(org, project, agentNames[i], req)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			found[i] = detectAnomalies(agentNames[i], envName, metrics, opts)
+		})
+
+		report := anomalyReport{
+			Project:     project,
+			Environment: envName,
+			StartTime:   req.StartTime,
+			EndTime:     req.EndTime,
+			Agents:      agentNames,
+		}
+		for i, anomalies := range found {
+			if errs[i] != nil {
+				if report.Errors == nil {
+					report.Errors = make(map[string]string)
+				}
+				report.Errors[agentNames[i]] = errs[i].Error()
+				continue
+			}
+			report.Anomalies = append(report.Anomalies, anomalies...)
+		}
+		sortAnomalies(report.Anomalies)
+
+		title := fmt.Sprintf("%s Metric Anomalies: %s (%s)", ui.IconMetrics, project, envName)
+		return writeAnomalyReport(title, report, output)
+	},
+}
+
+func init() {
+	metricsCmd.AddCommand(metricsAnomaliesCmd)
+
+	metricsAnomaliesCmd.Flags().StringP("env", "e", "", "Environment name (required)")
+	metricsAnomaliesCmd.Flags().StringArrayP("agent", "a", nil, "Agent to analyse (repeatable, default: all agents in the project)")
+	metricsAnomaliesCmd.Flags().String("since", "24h", "Usage history to analyse (e.g., 1h, 24h, 7d)")
+	metricsAnomaliesCmd.Flags().Int("concurrency", defaultLogsConcurrency, "Number of agents queried at once")
+	addAnomalyFlags(metricsAnomaliesCmd)
+}
@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Kavirubc/wso2-amp-cli/internal/api"
+)
+
+// Export formats accepted by --format on agents metrics
+const (
+	metricsFormatOpenMetrics = "openmetrics"
+	metricsFormatCSV         = "csv"
+)
+
+// openMetricsContentType is the scrape response content type
+const openMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
+// metricLabels identifies the agent a series belongs to
+type metricLabels struct {
+	Agent       string
+	Project     string
+	Environment string
+}
+
+// exportedMetric is one MetricsResponse series under its exported name
+type exportedMetric struct {
+	Name   string // OpenMetrics family name, including the unit suffix
+	Series string // short name used in CSV
+	Unit   string
+	Help   string
+	Points []api.MetricDataPoint
+}
+
+// exportedMetrics lists every series of a metrics response in a stable order
+func exportedMetrics(metrics *api.MetricsResponse) []exportedMetric {
+	return []exportedMetric{
+		{"amp_agent_cpu_usage_cores", "cpu.usage", "cores", "CPU usage of the agent in cores.", metrics.CpuUsage},
+		{"amp_agent_cpu_request_cores", "cpu.request", "cores", "CPU request of the agent in cores.", metrics.CpuRequests},
+		{"amp_agent_cpu_limit_cores", "cpu.limit", "cores", "CPU limit of the agent in cores.", metrics.CpuLimits},
+		{"amp_agent_memory_usage_bytes", "memory.usage", "bytes", "Memory usage of the agent in bytes.", metrics.Memory},
+		{"amp_agent_memory_request_bytes", "memory.request", "bytes", "Memory request of the agent in bytes.", metrics.MemoryRequests},
+		{"amp_agent_memory_limit_bytes", "memory.limit", "bytes", "Memory limit of the agent in bytes.", metrics.MemoryLimits},
+	}
+}
+
+// agentMetricsSample is the metrics of one agent, as written by the OpenMetrics encoder
+type agentMetricsSample struct {
+	Labels  metricLabels
+	Metrics *api.MetricsResponse
+}
+
+// writeOpenMetrics writes the samples in the OpenMetrics text format. With latestOnly
+// each series contributes only its newest point and no timestamp, as a scrape target
+// should; otherwise every point is written with its timestamp.
+func writeOpenMetrics(w io.Writer, samples []agentMetricsSample, latestOnly bool) error {
+	if len(samples) == 0 {
+		_, err := io.WriteString(w, "# EOF\n")
+		return err
+	}
+
+	// Samples of a metric family must be grouped together
+	families := exportedMetrics(samples[0].Metrics)
+	var b strings.Builder
+	for f, family := range families {
+		fmt.Fprintf(&b, "# TYPE %s gauge\n", family.Name)
+		fmt.Fprintf(&b, "# UNIT %s %s\n", family.Name, family.Unit)
+		fmt.Fprintf(&b, "# HELP %s %s\n", family.Name, family.Help)
+		for _, sample := range samples {
+			points := exportedMetrics(sample.Metrics)[f].Points
+			if latestOnly && len(points) > 0 {
+				points = points[len(points)-1:]
+			}
+			labels := formatOpenMetricsLabels(sample.Labels)
+			for _, p := range points {
+				fmt.Fprintf(&b, "%s%s %s", family.Name, labels, formatOpenMetricsValue(p.Value))
+				if !latestOnly {
+					ts, err := parseMetricTimestamp(p.Timestamp)
+					if err != nil {
+						return err
+					}
+					fmt.Fprintf(&b, " %s", strconv.FormatFloat(float64(ts.UnixMilli())/1000, 'f', -1, 64))
+				}
+				b.WriteString("\n")
+			}
+		}
+	}
+	b.WriteString("# EOF\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeMetricsCSV writes one row per data point
+func writeMetricsCSV(w io.Writer, samples []agentMetricsSample) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"agent", "project", "environment", "series", "unit", "timestamp", "value"}); err != nil {
+		return err
+	}
+	for _, sample := range samples {
+		for _, metric := range exportedMetrics(sample.Metrics) {
+			for _, p := range metric.Points {
+				ts, err := parseMetricTimestamp(p.Timestamp)
+				if err != nil {
+					return err
+				}
+				record := []string{
+					sample.Labels.Agent,
+					sample.Labels.Project,
+					sample.Labels.Environment,
+					metric.Series,
+					metric.Unit,
+					ts.UTC().Format(time.RFC3339),
+					strconv.FormatFloat(p.Value, 'f', -1, 64),
+				}
+				if err := cw.Write(record); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// parseMetricTimestamp parses an RFC3339 data point timestamp
+func parseMetricTimestamp(timestamp string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, timestamp)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid metric timestamp %q", timestamp)
+	}
+	return t, nil
+}
+
+// formatOpenMetricsLabels renders the label set of a sample
+func formatOpenMetricsLabels(l metricLabels) string {
+	return fmt.Sprintf(`{agent="%s",project="%s",environment="%s"}`,
+		escapeLabelValue(l.Agent), escapeLabelValue(l.Project), escapeLabelValue(l.Environment))
+}
+
+// escapeLabelValue escapes backslashes, quotes and newlines in a label value
+func escapeLabelValue(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v)
+}
+
+// formatOpenMetricsValue formats a gauge value, spelling out special values
+func formatOpenMetricsValue(v float64) string {
+	switch {
+	case math.IsNaN(v):
+		return "NaN"
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
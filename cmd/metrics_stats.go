@@ -0,0 +1,306 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Kavirubc/wso2-amp-cli/internal/api"
+	"github.com/Kavirubc/wso2-amp-cli/internal/ui"
+)
+
+// metricStats summarises one metric series
+type metricStats struct {
+	Series string  `json:"series"`
+	Count  int     `json:"count"`
+	Min    float64 `json:"min"`
+	Avg    float64 `json:"avg"`
+	P95    float64 `json:"p95"`
+	Max    float64 `json:"max"`
+	Last   float64 `json:"last"`
+	// Utilisation of the p95 value, in percent, for usage series
+	P95OfRequest *float64 `json:"p95PercentOfRequest,omitempty"`
+	P95OfLimit   *float64 `json:"p95PercentOfLimit,omitempty"`
+}
+
+// computeMetricStats calculates min/avg/p95/max for a series
+func computeMetricStats(name string, points []api.MetricDataPoint) (metricStats, bool) {
+	if len(points) == 0 {
+		return metricStats{Series: name}, false
+	}
+	values := metricValues(points)
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return metricStats{
+		Series: name,
+		Count:  len(values),
+		Min:    sorted[0],
+		Avg:    sum / float64(len(values)),
+		P95:    percentile(sorted, 95),
+		Max:    sorted[len(sorted)-1],
+		Last:   values[len(values)-1],
+	}, true
+}
+
+// percentile returns the nearest-rank percentile of sorted values
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// seriesStats holds the statistics of a resource's usage, request and limit
+type seriesStats struct {
+	Usage, Request, Limit          metricStats
+	hasUsage, hasRequest, hasLimit bool
+}
+
+// statsForSeries computes the statistics of a resource
+func statsForSeries(series metricSeries) seriesStats {
+	name := strings.ToLower(series.Name)
+	var s seriesStats
+	s.Usage, s.hasUsage = computeMetricStats(name+".usage", series.Usage)
+	s.Request, s.hasRequest = computeMetricStats(name+".request", series.Requests)
+	s.Limit, s.hasLimit = computeMetricStats(name+".limit", series.Limits)
+	if s.hasUsage && s.hasRequest && s.Request.Last > 0 {
+		pct := s.Usage.P95 / s.Request.Last * 100
+		s.Usage.P95OfRequest = &pct
+	}
+	if s.hasUsage && s.hasLimit && s.Limit.Last > 0 {
+		pct := s.Usage.P95 / s.Limit.Last * 100
+		s.Usage.P95OfLimit = &pct
+	}
+	return s
+}
+
+// collectMetricStats returns statistics for every series that has data
+func collectMetricStats(metrics *api.MetricsResponse) []metricStats {
+	var all []metricStats
+	for _, series := range agentMetricSeries(metrics) {
+		s := statsForSeries(series)
+		if s.hasUsage {
+			all = append(all, s.Usage)
+		}
+		if s.hasRequest {
+			all = append(all, s.Request)
+		}
+		if s.hasLimit {
+			all = append(all, s.Limit)
+		}
+	}
+	return all
+}
+
+// printMetricStats prints the statistics table
+func printMetricStats(stats []metricStats) {
+	if len(stats) == 0 {
+		return
+	}
+	headers := []string{"SERIES", "MIN", "AVG", "P95", "MAX", "P95 % REQUEST", "P95 % LIMIT"}
+	rows := make([][]string, len(stats))
+	for i, s := range stats {
+		format := ui.FormatMemoryValue
+		if strings.HasPrefix(s.Series, "cpu.") {
+			format = ui.FormatCPUValue
+		}
+		rows[i] = []string{
+			s.Series,
+			format(s.Min),
+			format(s.Avg),
+			format(s.P95),
+			format(s.Max),
+			formatPercent(s.P95OfRequest),
+			formatPercent(s.P95OfLimit),
+		}
+	}
+	fmt.Println(ui.SectionStyle.Render("  Statistics:"))
+	fmt.Println(ui.RenderTable(headers, rows))
+	fmt.Println()
+}
+
+func formatPercent(p *float64) string {
+	if p == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", *p)
+}
+
+// metricAssertion is a parsed --assert expression such as cpu.p95<80%limit
+type metricAssertion struct {
+	Expression string
+	Resource   string
+	Stat       string
+	Op         string
+	Value      float64
+	Relative   string // "request" or "limit" when Value is a fraction of it
+}
+
+// metricAssertionResult is the outcome of evaluating an assertion
+type metricAssertionResult struct {
+	Expression string  `json:"expression"`
+	Actual     float64 `json:"actual"`
+	Threshold  float64 `json:"threshold"`
+	Passed     bool    `json:"passed"`
+	Message    string  `json:"message"`
+}
+
+var assertionPattern = regexp.MustCompile(`^(cpu|memory|mem)\.(min|avg|p95|max|last)\s*(<=|>=|==|!=|<|>)\s*([0-9.]+)\s*([A-Za-z%]*)\s*$`)
+
+// Memory units accepted in assertions
+var memoryUnits = map[string]float64{
+	"":    1,
+	"b":   1,
+	"k":   1000,
+	"kb":  1000,
+	"ki":  1024,
+	"kib": 1024,
+	"m":   1000 * 1000,
+	"mb":  1000 * 1000,
+	"mi":  1024 * 1024,
+	"mib": 1024 * 1024,
+	"g":   1000 * 1000 * 1000,
+	"gb":  1000 * 1000 * 1000,
+	"gi":  1024 * 1024 * 1024,
+	"gib": 1024 * 1024 * 1024,
+}
+
+// parseMetricAssertion parses expressions like cpu.p95<80%limit, memory.max<512Mi or cpu.avg<250m
+func parseMetricAssertion(expr string) (metricAssertion, error) {
+	m := assertionPattern.FindStringSubmatch(strings.TrimSpace(expr))
+	if m == nil {
+		return metricAssertion{}, fmt.Errorf("invalid assertion %q: expected <cpu|memory>.<min|avg|p95|max|last><op><value>, e.g. cpu.p95<80%%limit", expr)
+	}
+	a := metricAssertion{Expression: strings.TrimSpace(expr), Resource: m[1], Stat: m[2], Op: m[3]}
+	if a.Resource == "mem" {
+		a.Resource = "memory"
+	}
+	number, err := strconv.ParseFloat(m[4], 64)
+	if err != nil {
+		return metricAssertion{}, fmt.Errorf("invalid assertion %q: bad number %q", expr, m[4])
+	}
+	unit := strings.ToLower(m[5])
+
+	switch {
+	case unit == "%limit" || unit == "%request":
+		a.Value = number / 100
+		a.Relative = strings.TrimPrefix(unit, "%")
+	case strings.HasPrefix(unit, "%"):
+		return metricAssertion{}, fmt.Errorf("invalid assertion %q: percentages must be of request or limit (e.g. 80%%limit)", expr)
+	case a.Resource == "cpu":
+		switch unit {
+		case "":
+			a.Value = number // cores
+		case "m":
+			a.Value = number / 1000
+		default:
+			return metricAssertion{}, fmt.Errorf("invalid assertion %q: CPU values are cores or millicores (e.g. 500m)", expr)
+		}
+	default:
+		factor, ok := memoryUnits[unit]
+		if !ok {
+			return metricAssertion{}, fmt.Errorf("invalid assertion %q: unknown memory unit %q (use Ki, Mi, Gi, KB, MB or GB)", expr, m[5])
+		}
+		a.Value = number * factor
+	}
+	return a, nil
+}
+
+// evaluateMetricAssertion checks an assertion against the metrics
+func evaluateMetricAssertion(a metricAssertion, metrics *api.MetricsResponse) metricAssertionResult {
+	result := metricAssertionResult{Expression: a.Expression}
+
+	var series metricSeries
+	for _, s := range agentMetricSeries(metrics) {
+		if strings.ToLower(s.Name) == a.Resource {
+			series = s
+		}
+	}
+	stats := statsForSeries(series)
+	if !stats.hasUsage {
+		result.Message = fmt.Sprintf("no %s usage data", a.Resource)
+		return result
+	}
+
+	switch a.Stat {
+	case "min":
+		result.Actual = stats.Usage.Min
+	case "avg":
+		result.Actual = stats.Usage.Avg
+	case "p95":
+		result.Actual = stats.Usage.P95
+	case "max":
+		result.Actual = stats.Usage.Max
+	case "last":
+		result.Actual = stats.Usage.Last
+	}
+
+	result.Threshold = a.Value
+	switch a.Relative {
+	case "limit":
+		if !stats.hasLimit || stats.Limit.Last <= 0 {
+			result.Message = fmt.Sprintf("no %s limit configured", a.Resource)
+			return result
+		}
+		result.Threshold = a.Value * stats.Limit.Last
+	case "request":
+		if !stats.hasRequest || stats.Request.Last <= 0 {
+			result.Message = fmt.Sprintf("no %s request configured", a.Resource)
+			return result
+		}
+		result.Threshold = a.Value * stats.Request.Last
+	}
+
+	switch a.Op {
+	case "<":
+		result.Passed = result.Actual < result.Threshold
+	case "<=":
+		result.Passed = result.Actual <= result.Threshold
+	case ">":
+		result.Passed = result.Actual > result.Threshold
+	case ">=":
+		result.Passed = result.Actual >= result.Threshold
+	case "==":
+		result.Passed = result.Actual == result.Threshold
+	case "!=":
+		result.Passed = result.Actual != result.Threshold
+	}
+
+	result.Message = fmt.Sprintf("%s %s %s %s", a.Stat, series.Format(result.Actual), a.Op, series.Format(result.Threshold))
+	return result
+}
+
+// printAssertionResults prints one line per assertion
+func printAssertionResults(results []metricAssertionResult) {
+	fmt.Println(ui.SectionStyle.Render("  Assertions:"))
+	for _, r := range results {
+		if r.Passed {
+			fmt.Println("  " + ui.RenderSuccess(fmt.Sprintf("%s  (%s)", r.Expression, r.Message)))
+		} else {
+			fmt.Println("  " + ui.RenderError(fmt.Sprintf("%s  (%s)", r.Expression, r.Message)))
+		}
+	}
+	fmt.Println()
+}
+
+// metricsReport is the JSON output of --view stats and --assert
+type metricsReport struct {
+	Agent       string                  `json:"agent"`
+	Environment string                  `json:"environment"`
+	StartTime   string                  `json:"startTime"`
+	EndTime     string                  `json:"endTime"`
+	Stats       []metricStats           `json:"stats"`
+	Assertions  []metricAssertionResult `json:"assertions,omitempty"`
+}
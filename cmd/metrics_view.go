@@ -0,0 +1,238 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/Kavirubc/wso2-amp-cli/internal/api"
+	"github.com/Kavirubc/wso2-amp-cli/internal/ui"
+	"github.com/charmbracelet/x/term"
+)
+
+// Metrics views accepted by --view
+const (
+	metricsViewChart     = "chart"
+	metricsViewSparkline = "sparkline"
+	metricsViewTable     = "table"
+	metricsViewStats     = "stats"
+)
+
+// defaultMetricsWatchInterval is the refresh interval for --watch
+const defaultMetricsWatchInterval = 10 * time.Second
+
+// Chart dimensions
+const (
+	defaultTerminalWidth = 100
+	maxChartWidth        = 160
+	metricsChartHeight   = 8
+)
+
+// metricSeries is one resource (CPU or memory) with its request and limit
+type metricSeries struct {
+	Name     string
+	Usage    []api.MetricDataPoint
+	Requests []api.MetricDataPoint
+	Limits   []api.MetricDataPoint
+	Format   func(float64) string
+}
+
+// agentMetricSeries splits a metrics response into CPU and memory series
+func agentMetricSeries(metrics *api.MetricsResponse) []metricSeries {
+	return []metricSeries{
+		{Name: "CPU", Usage: metrics.CpuUsage, Requests: metrics.CpuRequests, Limits: metrics.CpuLimits, Format: ui.FormatCPUValue},
+		{Name: "Memory", Usage: metrics.Memory, Requests: metrics.MemoryRequests, Limits: metrics.MemoryLimits, Format: ui.FormatMemoryValue},
+	}
+}
+
+// hasData reports whether the series has any points
+func (s metricSeries) hasData() bool {
+	return len(s.Usage) > 0 || len(s.Requests) > 0 || len(s.Limits) > 0
+}
+
+// references returns the latest request and limit as chart reference lines
+func (s metricSeries) references() []ui.ChartReference {
+	var refs []ui.ChartReference
+	if len(s.Requests) > 0 {
+		refs = append(refs, ui.ChartReference{Name: "request", Value: s.Requests[len(s.Requests)-1].Value, Color: ui.ChartRequestColor})
+	}
+	if len(s.Limits) > 0 {
+		refs = append(refs, ui.ChartReference{Name: "limit", Value: s.Limits[len(s.Limits)-1].Value, Color: ui.ChartLimitColor})
+	}
+	return refs
+}
+
+// metricValues extracts the values of data points
+func metricValues(points []api.MetricDataPoint) []float64 {
+	values := make([]float64, len(points))
+	for i, p := range points {
+		values[i] = p.Value
+	}
+	return values
+}
+
+// terminalWidth returns the width of stdout, or a default when it is not a terminal
+func terminalWidth() int {
+	if w, _, err := term.GetSize(os.Stdout.Fd()); err == nil && w > 0 {
+		return w
+	}
+	return defaultTerminalWidth
+}
+
+// printAgentMetrics renders metrics in the selected view
+func printAgentMetrics(metrics *api.MetricsResponse, req api.MetricsFilterRequest, agentName, envName, view string) {
+	fmt.Println(ui.TitleStyle.Render(fmt.Sprintf("%s Resource Metrics for %s (%s)", ui.IconMetrics, agentName, envName)))
+	fmt.Println()
+
+	// Show time range
+	startDisplay := ui.FormatMetricTimestamp(req.StartTime)
+	endDisplay := ui.FormatMetricTimestamp(req.EndTime)
+	fmt.Printf("  %s  %s - %s\n", ui.KeyStyle.Render("Time Range:"), startDisplay, endDisplay)
+	fmt.Println()
+
+	width := terminalWidth()
+	if width > maxChartWidth {
+		width = maxChartWidth
+	}
+
+	if view == metricsViewStats {
+		printMetricStats(collectMetricStats(metrics))
+		return
+	}
+
+	for _, series := range agentMetricSeries(metrics) {
+		if !series.hasData() {
+			continue
+		}
+		switch view {
+		case metricsViewTable:
+			printMetricTable(series)
+		case metricsViewSparkline:
+			printMetricSparkline(series, width)
+		default:
+			printMetricChart(series, width)
+		}
+	}
+	printMetricStats(collectMetricStats(metrics))
+}
+
+// printMetricTable prints the raw time-series rows
+func printMetricTable(series metricSeries) {
+	fmt.Println(ui.SectionStyle.Render(fmt.Sprintf("  %s Usage:", series.Name)))
+	var headers []string
+	var rows [][]string
+	if series.Name == "CPU" {
+		headers, rows = ui.BuildCPUMetricsTable(series.Usage, series.Requests, series.Limits)
+	} else {
+		headers, rows = ui.BuildMemoryMetricsTable(series.Usage, series.Requests, series.Limits)
+	}
+	if len(rows) > 0 {
+		fmt.Println(ui.RenderTable(headers, rows))
+	}
+	fmt.Println()
+}
+
+// printMetricChart prints an area chart with request and limit reference lines
+func printMetricChart(series metricSeries, width int) {
+	refs := series.references()
+	fmt.Println(ui.SectionStyle.Render(fmt.Sprintf("  %s Usage:", series.Name)))
+	fmt.Println("  " + ui.ChartLegend("usage", refs, series.Format))
+	fmt.Println()
+
+	var startLabel, endLabel string
+	if len(series.Usage) > 0 {
+		startLabel = ui.FormatMetricTimestamp(series.Usage[0].Timestamp)
+		endLabel = ui.FormatMetricTimestamp(series.Usage[len(series.Usage)-1].Timestamp)
+	}
+	chart := ui.RenderChart(ui.Chart{
+		Values:     metricValues(series.Usage),
+		References: refs,
+		// Leave room for the reference labels on the right
+		Width:      width - 22,
+		Height:     metricsChartHeight,
+		Format:     series.Format,
+		StartLabel: startLabel,
+		EndLabel:   endLabel,
+	})
+	for _, line := range strings.Split(strings.TrimRight(chart, "\n"), "\n") {
+		fmt.Println("  " + line)
+	}
+	fmt.Println("  " + metricStatsLine(series))
+	fmt.Println()
+}
+
+// printMetricSparkline prints a one-line sparkline with current, average and peak usage
+func printMetricSparkline(series metricSeries, width int) {
+	sparkWidth := width - 60
+	if sparkWidth < 10 {
+		sparkWidth = 10
+	}
+	spark := ui.Sparkline(metricValues(series.Usage), sparkWidth)
+	fmt.Printf("  %s  %s  %s\n",
+		ui.KeyStyle.Render(series.Name+":"),
+		ui.InfoStyle.Render(spark),
+		metricStatsLine(series))
+	fmt.Println()
+}
+
+// metricStatsLine summarises current, average and peak usage against the limit
+func metricStatsLine(series metricSeries) string {
+	if len(series.Usage) == 0 {
+		return ui.MutedStyle.Render("no usage data")
+	}
+	values := metricValues(series.Usage)
+	current := values[len(values)-1]
+	sum, peak := 0.0, values[0]
+	for _, v := range values {
+		sum += v
+		if v > peak {
+			peak = v
+		}
+	}
+	parts := []string{
+		"current " + series.Format(current),
+		"avg " + series.Format(sum/float64(len(values))),
+		"peak " + series.Format(peak),
+	}
+	if len(series.Limits) > 0 {
+		if limit := series.Limits[len(series.Limits)-1].Value; limit > 0 {
+			parts = append(parts, fmt.Sprintf("%.0f%% of limit", current/limit*100))
+		}
+	}
+	return ui.MutedStyle.Render(strings.Join(parts, " • "))
+}
+
+// watchAgentMetrics redraws the metrics every interval until Ctrl-C
+func watchAgentMetrics(fetch func() (*api.MetricsResponse, error), req *api.MetricsFilterRequest, agentName, envName, view string, interval time.Duration) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if interval <= 0 {
+		interval = defaultMetricsWatchInterval
+	}
+
+	for {
+		metrics, err := fetch()
+
+		// Clear the screen and redraw from the top
+		fmt.Print("\033[H\033[2J")
+		switch {
+		case err != nil:
+			fmt.Println(ui.RenderWarning(err.Error()))
+		case !ui.HasMetricsData(metrics):
+			fmt.Println(ui.RenderWarning("No metrics data found for the specified criteria."))
+		default:
+			printAgentMetrics(metrics, *req, agentName, envName, view)
+		}
+		fmt.Println(ui.MutedStyle.Render(fmt.Sprintf("Refreshing every %s. Last update %s. Press Ctrl+C to stop.", interval, time.Now().Format("15:04:05"))))
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
@@ -155,6 +155,62 @@ func printPipelineRow(key, value string) {
 	fmt.Printf("  %s  %s\n", ui.KeyStyle.Render(key), ui.ValueStyle.Render(value))
 }
 
+// orderPipelineEnvironments returns the pipeline's environments in promotion order,
+// starting from environments that are never a promotion target
+func orderPipelineEnvironments(pipeline *api.DeploymentPipelineResponse) []string {
+	if pipeline == nil {
+		return nil
+	}
+
+	// Collect edges and note which environments are promotion targets
+	next := make(map[string][]string)
+	isTarget := make(map[string]bool)
+	var all []string
+	seen := make(map[string]bool)
+	add := func(env string) {
+		if env != "" && !seen[env] {
+			seen[env] = true
+			all = append(all, env)
+		}
+	}
+	for _, path := range pipeline.PromotionPaths {
+		add(path.SourceEnvironmentRef)
+		for _, t := range path.TargetEnvironmentRefs {
+			add(t.Name)
+			next[path.SourceEnvironmentRef] = append(next[path.SourceEnvironmentRef], t.Name)
+			isTarget[t.Name] = true
+		}
+	}
+
+	// Breadth-first walk from the root environments
+	var queue []string
+	for _, env := range all {
+		if !isTarget[env] {
+			queue = append(queue, env)
+		}
+	}
+	visited := make(map[string]bool)
+	var ordered []string
+	for len(queue) > 0 {
+		env := queue[0]
+		queue = queue[1:]
+		if visited[env] {
+			continue
+		}
+		visited[env] = true
+		ordered = append(ordered, env)
+		queue = append(queue, next[env]...)
+	}
+
+	// Append anything unreachable (e.g. cycles) in declaration order
+	for _, env := range all {
+		if !visited[env] {
+			ordered = append(ordered, env)
+		}
+	}
+	return ordered
+}
+
 func init() {
 	rootCmd.AddCommand(pipelinesCmd)
 	pipelinesCmd.AddCommand(pipelinesListCmd)
@@ -0,0 +1,301 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Kavirubc/wso2-amp-cli/internal/api"
+	"github.com/Kavirubc/wso2-amp-cli/internal/config"
+	"github.com/Kavirubc/wso2-amp-cli/internal/ui"
+	"github.com/charmbracelet/x/term"
+	"github.com/spf13/cobra"
+)
+
+// pipelinePromotionTargets lists the environments the pipeline allows
+// promoting to from the source environment
+func pipelinePromotionTargets(pipeline *api.DeploymentPipelineResponse, from string) []string {
+	var targets []string
+	for _, path := range pipeline.PromotionPaths {
+		if path.SourceEnvironmentRef != from {
+			continue
+		}
+		for _, t := range path.TargetEnvironmentRefs {
+			targets = append(targets, t.Name)
+		}
+	}
+	return targets
+}
+
+// choosePromotionTarget validates --to against the pipeline, or picks the
+// target when it is not given
+func choosePromotionTarget(pipeline *api.DeploymentPipelineResponse, from, to string, source api.DeploymentDetails) (string, error) {
+	allowed := pipelinePromotionTargets(pipeline, from)
+	if len(allowed) == 0 {
+		return "", fmt.Errorf("deployment pipeline %q does not promote from %s", pipeline.Name, from)
+	}
+
+	if to == "" {
+		// Prefer the target the API suggests for this deployment
+		if source.PromotionTargetEnvironment != nil && source.PromotionTargetEnvironment.Name != "" {
+			to = source.PromotionTargetEnvironment.Name
+		} else if len(allowed) == 1 {
+			to = allowed[0]
+		} else {
+			return "", fmt.Errorf("%s promotes to several environments; choose one with --to: %s", from, strings.Join(allowed, ", "))
+		}
+	}
+
+	for _, env := range allowed {
+		if env == to {
+			return to, nil
+		}
+	}
+	return "", fmt.Errorf("deployment pipeline %q does not allow promoting from %s to %s; allowed: %s",
+		pipeline.Name, from, to, strings.Join(allowed, ", "))
+}
+
+// findEnvironment pages through the organization's environments for one by
+// name. A missing environment is an error, so callers never mistake it for
+// one that is not production.
+func findEnvironment(client *api.Client, org, name string) (api.Environment, error) {
+	const pageSize = 100
+	seen := 0
+	for offset := 0; ; offset += pageSize {
+		environments, total, err := client.ListEnvironments(org, api.ListOptions{Limit: pageSize, Offset: offset})
+		if err != nil {
+			return api.Environment{}, err
+		}
+		for _, env := range environments {
+			if env.Name == name {
+				return env, nil
+			}
+		}
+		seen += len(environments)
+		if len(environments) == 0 || seen >= total {
+			return api.Environment{}, fmt.Errorf("environment %s not found in organization %s", name, org)
+		}
+	}
+}
+
+// confirmProductionDeployment asks before deploying to a production environment
+func confirmProductionDeployment(client *api.Client, org, agent, target, imageID string, force bool) (bool, error) {
+	if force {
+		return true, nil
+	}
+
+	env, err := findEnvironment(client, org, target)
+	if err != nil {
+		return false, fmt.Errorf("failed to check environments: %w", err)
+	}
+	if !env.IsProduction {
+		return true, nil
+	}
+	// Without a terminal nobody can answer, and exiting 0 would hide that nothing was deployed
+	if !term.IsTerminal(os.Stdin.Fd()) {
+		return false, fmt.Errorf("cannot confirm the deployment to production environment %s: stdin is not a terminal. Use --force to deploy without confirmation", target)
+	}
+
+	// Prompt on stderr so JSON output on stdout stays clean
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, ui.WarningStyle.Render("⚠️  You are about to deploy to a production environment"))
+	fmt.Fprintf(os.Stderr, "   Agent:       %s\n", agent)
+	fmt.Fprintf(os.Stderr, "   Environment: %s\n", target)
+	fmt.Fprintf(os.Stderr, "   Image:       %s\n", imageID)
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprint(os.Stderr, "Are you sure? [y/N]: ")
+	reader := bufio.NewReader(os.Stdin)
+	response, _ := reader.ReadString('\n')
+	response = strings.TrimSpace(strings.ToLower(response))
+	if response != "y" && response != "yes" {
+		fmt.Fprintln(os.Stderr, ui.RenderWarning("Cancelled. Nothing was deployed."))
+		return false, nil
+	}
+	fmt.Fprintln(os.Stderr)
+	return true, nil
+}
+
+var promoteCmd = &cobra.Command{
+	Use:   "promote",
+	Short: "Promote an agent to the next environment of the pipeline",
+	Long: `Promote the image running in one environment to the next environment of
+the project's deployment pipeline.
+
+The target must be a promotion target of the source environment in the
+pipeline. Without --to, the command uses the target the API suggests for the
+source deployment, or the only target the pipeline allows. The target keeps
+its own configuration: the environment variables set for it are deployed
+with the promoted image. Promoting to a production environment asks for
+confirmation unless --force is given.
+
+After the request the command checks that the target reports the promoted
+image, and fails if it does not.
+
+Examples:
+  amp promote --agent myagent --from development
+  amp promote --agent myagent --from staging --to production
+  amp promote --agent myagent --from staging --to production --force --wait`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flags
+		org, _ := cmd.Flags().GetString("org")
+		project, _ := cmd.Flags().GetString("project")
+		agent, _ := cmd.Flags().GetString("agent")
+		from, _ := cmd.Flags().GetString("from")
+		to, _ := cmd.Flags().GetString("to")
+		force, _ := cmd.Flags().GetBool("force")
+		wait, _ := cmd.Flags().GetBool("wait")
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		output, _ := cmd.Flags().GetString("output")
+
+		// Use defaults from config if not provided
+		if org == "" {
+			org = config.GetDefaultOrg()
+		}
+		if project == "" {
+			project = config.GetDefaultProject()
+		}
+
+		// Validate required fields
+		if org == "" {
+			return fmt.Errorf("organization is required. Use --org flag or set default with: amp config set default_org <name>")
+		}
+		if project == "" {
+			return fmt.Errorf("project is required. Use --project flag or set default with: amp config set default_project <name>")
+		}
+		if agent == "" {
+			return fmt.Errorf("agent name is required. Use --agent flag")
+		}
+		if from == "" {
+			return fmt.Errorf("source environment is required. Use --from flag")
+		}
+
+		// Create API client
+		client := api.NewClient(
+			config.GetAPIURL(),
+			config.GetAPIKeyHeader(),
+			config.GetAPIKeyValue(),
+		)
+
+		// The image comes from what the source environment runs now
+		deployments, err := client.GetDeploymentsMap(org, project, agent)
+		if err != nil {
+			return fmt.Errorf("failed to get deployments: %w", err)
+		}
+		source, ok := deployments[from]
+		if !ok || source.ImageID == "" {
+			return fmt.Errorf("agent %s is not deployed to %s", agent, from)
+		}
+
+		pipeline, err := client.GetProjectDeploymentPipeline(org, project)
+		if err != nil {
+			return fmt.Errorf("failed to get deployment pipeline: %w", err)
+		}
+		to, err = choosePromotionTarget(pipeline, from, to, source)
+		if err != nil {
+			return err
+		}
+
+		if target, ok := deployments[to]; ok && target.ImageID == source.ImageID {
+			fmt.Fprintln(os.Stderr, ui.RenderInfo(fmt.Sprintf("%s already runs %s; nothing to promote", to, source.ImageID)))
+			return nil
+		}
+		if rolloutState(source, source.ImageID) != rolloutReady {
+			fmt.Fprintln(os.Stderr, ui.RenderWarning(fmt.Sprintf("%s is %s, not active; promoting its image anyway", from, valueOrDefault(source.Status, "in an unknown state"))))
+		}
+
+		// The target environment keeps its own configuration
+		targetConfig, err := client.GetAgentConfigurations(org, project, agent, to)
+		if err != nil {
+			return fmt.Errorf("failed to get configuration of %s: %w", to, err)
+		}
+
+		ok, err = confirmProductionDeployment(client, org, agent, to, source.ImageID, force)
+		if err != nil {
+			cmd.SilenceUsage = true
+			return err
+		}
+		if !ok {
+			return nil
+		}
+
+		rollout := &rolloutWaiter{
+			client:  client,
+			org:     org,
+			project: project,
+			agent:   agent,
+			env:     to,
+			imageID: source.ImageID,
+		}
+		rollout.markRequested(deployments)
+
+		req := api.DeployAgentRequest{
+			ImageId: source.ImageID,
+			Env:     targetConfig.Configurations,
+		}
+		if err := client.DeployAgentToEnvironment(org, project, agent, to, req); err != nil {
+			return fmt.Errorf("failed to promote agent: %w", err)
+		}
+		// Only claim the promotion and record it once the target runs the image
+		if err := rollout.confirmPickedUp(); err != nil {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("failed to promote agent: %w", err)
+		}
+		recordDeployment(org, project, agent, to, source.ImageID, recordedBuild(org, project, agent, from, source.ImageID), "promote")
+
+		status := rollout.details.Status
+		if wait {
+			if err := waitForRollout(rollout, timeout); err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+			status = rollout.details.Status
+		}
+
+		// JSON output
+		if output == "json" {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(map[string]interface{}{
+				"agent":     agent,
+				"from":      from,
+				"to":        to,
+				"imageId":   source.ImageID,
+				"variables": len(targetConfig.Configurations),
+				"status":    status,
+			})
+		}
+
+		// Success message
+		fmt.Println(ui.RenderSuccess(fmt.Sprintf("Promoted %s from %s to %s!", agent, from, to)))
+		fmt.Println()
+		printDeployRow("Image:", source.ImageID)
+		printDeployRow("Configuration:", fmt.Sprintf("%d variable(s) of %s", len(targetConfig.Configurations), to))
+		if wait {
+			printDeployRow("Status:", ui.StatusCell(strings.ToLower(status)))
+		}
+		fmt.Println()
+
+		// Show next steps
+		fmt.Println(ui.SubtitleStyle.Render("Next steps:"))
+		fmt.Printf("  • View deployments: amp deployments list --agent %s\n", agent)
+		if next := pipelinePromotionTargets(pipeline, to); len(next) > 0 {
+			fmt.Printf("  • Promote further: amp promote --agent %s --from %s\n", agent, to)
+		}
+		fmt.Println()
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(promoteCmd)
+
+	promoteCmd.Flags().StringP("agent", "a", "", "Agent name (required)")
+	promoteCmd.Flags().String("from", "", "Environment to take the running image from (required)")
+	promoteCmd.Flags().String("to", "", "Environment to promote to (defaults to the pipeline's next environment)")
+	promoteCmd.Flags().BoolP("force", "f", false, "Skip the confirmation prompt for production environments")
+	promoteCmd.Flags().Bool("wait", false, "Wait until the target environment runs the promoted image")
+	promoteCmd.Flags().Duration("timeout", defaultDeployWaitTimeout, "How long --wait waits for the rollout")
+}
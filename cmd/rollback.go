@@ -0,0 +1,341 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"strings"
+
+	"github.com/Kavirubc/wso2-amp-cli/internal/api"
+	"github.com/Kavirubc/wso2-amp-cli/internal/config"
+	"github.com/Kavirubc/wso2-amp-cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// deploymentUser names who deployed, for the local history
+func deploymentUser() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
+
+// recordDeployment adds a deployment to the local history. Failing to record
+// only warns, since the deployment itself went through.
+func recordDeployment(org, project, agent, env, imageID string, build *api.BuildResponse, command string) {
+	rec := config.DeploymentRecord{
+		Org:         org,
+		Project:     project,
+		Agent:       agent,
+		Environment: env,
+		ImageID:     imageID,
+		User:        deploymentUser(),
+		Command:     command,
+	}
+	if build != nil {
+		rec.Build = build.Name
+		rec.CommitID = build.CommitID
+	}
+	if err := config.RecordDeployment(rec); err != nil {
+		fmt.Fprintln(os.Stderr, ui.RenderWarning(fmt.Sprintf("Deployment not recorded in the local history: %v", err)))
+	}
+}
+
+// recordedBuild finds the build an image came from in the local history of
+// an environment, so promotions and rollbacks keep track of it
+func recordedBuild(org, project, agent, env, imageID string) *api.BuildResponse {
+	history, err := config.DeploymentHistory(org, project, agent, env)
+	if err != nil {
+		return nil
+	}
+	for i := len(history) - 1; i >= 0; i-- {
+		if rec := history[i]; rec.ImageID == imageID && rec.Build != "" {
+			return &api.BuildResponse{Name: rec.Build, CommitID: rec.CommitID}
+		}
+	}
+	return nil
+}
+
+// previousDeployment returns the newest recorded deployment of an image other
+// than the current one. Rollbacks are not candidates, and neither is an image
+// a rollback moved away from until it is deployed again, so repeated
+// rollbacks keep walking backwards.
+func previousDeployment(history []config.DeploymentRecord, currentImage string) (config.DeploymentRecord, bool) {
+	rolledBack := make(map[string]bool)
+	running := ""
+	for _, rec := range history {
+		if rec.Command == "rollback" {
+			if running != "" && running != rec.ImageID {
+				rolledBack[running] = true
+			}
+		} else {
+			delete(rolledBack, rec.ImageID)
+		}
+		running = rec.ImageID
+	}
+
+	for i := len(history) - 1; i >= 0; i-- {
+		rec := history[i]
+		if rec.Command != "rollback" && rec.ImageID != currentImage && !rolledBack[rec.ImageID] {
+			return rec, true
+		}
+	}
+	return config.DeploymentRecord{}, false
+}
+
+// latestRecordOf returns the newest recorded deployment of an image
+func latestRecordOf(history []config.DeploymentRecord, imageID string) (config.DeploymentRecord, bool) {
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].ImageID == imageID {
+			return history[i], true
+		}
+	}
+	return config.DeploymentRecord{}, false
+}
+
+// describeRecord summarises where an image came from, e.g.
+// "build my-build-3, deployed 2d ago by alice"
+func describeRecord(rec config.DeploymentRecord, ok bool) string {
+	if !ok {
+		return "not in the local history"
+	}
+	var parts []string
+	if rec.Build != "" {
+		parts = append(parts, "build "+rec.Build)
+	}
+	deployed := "deployed " + formatAge(rec.Time)
+	if rec.User != "" {
+		deployed += " by " + rec.User
+	}
+	return strings.Join(append(parts, deployed), ", ")
+}
+
+// resolveRollbackTarget turns --to into an image. A value already in the
+// history or that looks like an image reference is an image; anything else
+// is a build name.
+func resolveRollbackTarget(client *api.Client, org, project, agent, to string, history []config.DeploymentRecord) (string, *api.BuildResponse, error) {
+	if rec, ok := latestRecordOf(history, to); ok {
+		return to, recordBuildRef(rec), nil
+	}
+	if strings.ContainsAny(to, ":/@") {
+		return to, nil, nil
+	}
+
+	build, err := client.GetBuild(org, project, agent, to)
+	if err != nil {
+		return "", nil, fmt.Errorf("%q is neither a recorded image nor a build: %w", to, err)
+	}
+	if buildOutcome(build.Status) != buildOutcomeSucceeded {
+		return "", nil, fmt.Errorf("build %s has status %s; only successful builds can be deployed", build.Name, valueOrDefault(build.Status, "unknown"))
+	}
+	if build.ImageID == "" {
+		return "", nil, fmt.Errorf("build %s has no image ID", build.Name)
+	}
+	return build.ImageID, &build.BuildResponse, nil
+}
+
+// recordBuildRef returns the build of a record, if it has one
+func recordBuildRef(rec config.DeploymentRecord) *api.BuildResponse {
+	if rec.Build == "" {
+		return nil
+	}
+	return &api.BuildResponse{Name: rec.Build, CommitID: rec.CommitID}
+}
+
+var rollbackCmd = &cobra.Command{
+	Use:   "rollback",
+	Short: "Redeploy the previous image of an environment",
+	Long: `Redeploy the image an environment ran before its current one, and wait
+until the rollout is ready.
+
+Once the environment reports the new image, amp deploy (with --env or
+--wait), promote and rollback record the image, time, user and build in a
+local history (~/.amp/deployments.jsonl). By default rollback
+picks the newest recorded image that differs from the one the environment
+runs now, skipping earlier rollbacks and the images they moved away from, so
+rolling back again goes further back. Use --to to roll back to a specific build or image instead. The
+environment keeps its own configuration. Rolling back a production
+environment asks for confirmation unless --force is given.
+
+The rollback is recorded once the rollout is ready. With --no-wait it is not
+recorded, since nothing checks that the environment runs the image.
+
+Examples:
+  amp rollback --agent myagent --env production
+  amp rollback --agent myagent --env production --to myagent-build-41
+  amp rollback --agent myagent --env staging --to registry/myagent:abc123 --force`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get flags
+		org, _ := cmd.Flags().GetString("org")
+		project, _ := cmd.Flags().GetString("project")
+		agent, _ := cmd.Flags().GetString("agent")
+		envName, _ := cmd.Flags().GetString("env")
+		to, _ := cmd.Flags().GetString("to")
+		force, _ := cmd.Flags().GetBool("force")
+		noWait, _ := cmd.Flags().GetBool("no-wait")
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		output, _ := cmd.Flags().GetString("output")
+
+		// Use defaults from config if not provided
+		if org == "" {
+			org = config.GetDefaultOrg()
+		}
+		if project == "" {
+			project = config.GetDefaultProject()
+		}
+
+		// Validate required fields
+		if org == "" {
+			return fmt.Errorf("organization is required. Use --org flag or set default with: amp config set default_org <name>")
+		}
+		if project == "" {
+			return fmt.Errorf("project is required. Use --project flag or set default with: amp config set default_project <name>")
+		}
+		if agent == "" {
+			return fmt.Errorf("agent name is required. Use --agent flag")
+		}
+		if envName == "" {
+			return fmt.Errorf("environment name is required. Use --env flag")
+		}
+
+		// Create API client
+		client := api.NewClient(
+			config.GetAPIURL(),
+			config.GetAPIKeyHeader(),
+			config.GetAPIKeyValue(),
+		)
+
+		deployments, err := client.GetDeploymentsMap(org, project, agent)
+		if err != nil {
+			return fmt.Errorf("failed to get deployments: %w", err)
+		}
+		current := deployments[envName]
+
+		history, err := config.DeploymentHistory(org, project, agent, envName)
+		if err != nil {
+			return fmt.Errorf("failed to read deployment history: %w", err)
+		}
+
+		// Pick the image to go back to
+		var imageID string
+		var build *api.BuildResponse
+		if to != "" {
+			imageID, build, err = resolveRollbackTarget(client, org, project, agent, to, history)
+			if err != nil {
+				return err
+			}
+		} else {
+			rec, ok := previousDeployment(history, current.ImageID)
+			if !ok {
+				return fmt.Errorf("no earlier image of %s in %s is in the local history (%s). Use --to with a build or image",
+					agent, envName, config.DeploymentHistoryFile())
+			}
+			imageID, build = rec.ImageID, recordBuildRef(rec)
+		}
+		if imageID == current.ImageID {
+			fmt.Fprintln(os.Stderr, ui.RenderInfo(fmt.Sprintf("%s already runs %s; nothing to roll back", envName, imageID)))
+			return nil
+		}
+
+		// Show what will change, on stderr so JSON output stays clean
+		currentRec, currentOK := latestRecordOf(history, current.ImageID)
+		targetRec, targetOK := latestRecordOf(history, imageID)
+		fmt.Fprintln(os.Stderr, ui.TitleStyle.Render(fmt.Sprintf("%s Rollback %s in %s", ui.IconAgent, agent, envName)))
+		fmt.Fprintln(os.Stderr)
+		fmt.Fprintf(os.Stderr, "  %s  %s\n", ui.KeyStyle.Render("Current:"), ui.ValueStyle.Render(valueOrDefault(current.ImageID, "(not deployed)")))
+		if current.ImageID != "" {
+			fmt.Fprintf(os.Stderr, "  %s  %s\n", ui.KeyStyle.Render(""), ui.MutedStyle.Render(describeRecord(currentRec, currentOK)))
+		}
+		fmt.Fprintf(os.Stderr, "  %s  %s\n", ui.KeyStyle.Render("Roll back to:"), ui.ValueStyle.Render(imageID))
+		targetDesc := describeRecord(targetRec, targetOK)
+		if !targetOK && build != nil {
+			targetDesc = fmt.Sprintf("build %s, commit %s", build.Name, valueOrDefault(truncateCommit(build.CommitID), "unknown"))
+		}
+		fmt.Fprintf(os.Stderr, "  %s  %s\n", ui.KeyStyle.Render(""), ui.MutedStyle.Render(targetDesc))
+		fmt.Fprintln(os.Stderr)
+
+		ok, err := confirmProductionDeployment(client, org, agent, envName, imageID, force)
+		if err != nil {
+			cmd.SilenceUsage = true
+			return err
+		}
+		if !ok {
+			return nil
+		}
+
+		// The environment keeps its own configuration
+		envConfig, err := client.GetAgentConfigurations(org, project, agent, envName)
+		if err != nil {
+			return fmt.Errorf("failed to get configuration of %s: %w", envName, err)
+		}
+
+		rollout := &rolloutWaiter{
+			client:  client,
+			org:     org,
+			project: project,
+			agent:   agent,
+			env:     envName,
+			imageID: imageID,
+		}
+		rollout.markRequested(deployments)
+
+		req := api.DeployAgentRequest{
+			ImageId: imageID,
+			Env:     envConfig.Configurations,
+		}
+		if err := client.DeployAgentToEnvironment(org, project, agent, envName, req); err != nil {
+			return fmt.Errorf("failed to roll back agent: %w", err)
+		}
+
+		// The history only records what the environment reported running, since
+		// later rollbacks choose their image from it
+		status := "requested"
+		if !noWait {
+			if err := waitForRollout(rollout, timeout); err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+			status = rollout.details.Status
+			recordDeployment(org, project, agent, envName, imageID, build, "rollback")
+		} else {
+			fmt.Fprintln(os.Stderr, ui.RenderInfo("Not recorded in the local history, since --no-wait does not check that the environment runs the image"))
+		}
+
+		// JSON output
+		if output == "json" {
+			result := map[string]string{
+				"agent":         agent,
+				"environment":   envName,
+				"previousImage": current.ImageID,
+				"imageId":       imageID,
+				"status":        status,
+			}
+			if build != nil {
+				result["build"] = build.Name
+			}
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(result)
+		}
+
+		if noWait {
+			fmt.Println(ui.RenderSuccess(fmt.Sprintf("Rollback of %s in %s to %s requested", agent, envName, imageID)))
+		} else {
+			fmt.Println(ui.RenderSuccess(fmt.Sprintf("Rolled back %s in %s to %s", agent, envName, imageID)))
+		}
+		fmt.Println()
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(rollbackCmd)
+
+	rollbackCmd.Flags().StringP("agent", "a", "", "Agent name (required)")
+	rollbackCmd.Flags().StringP("env", "e", "", "Environment to roll back (required)")
+	rollbackCmd.Flags().String("to", "", "Build name or image to roll back to (defaults to the previous image)")
+	rollbackCmd.Flags().BoolP("force", "f", false, "Skip the confirmation prompt for production environments")
+	rollbackCmd.Flags().Bool("no-wait", false, "Return once the deployment is accepted instead of waiting for the rollout")
+	rollbackCmd.Flags().Duration("timeout", defaultDeployWaitTimeout, "How long to wait for the rollout")
+}
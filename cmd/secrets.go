@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Kavirubc/wso2-amp-cli/internal/api"
+	"github.com/Kavirubc/wso2-amp-cli/internal/config"
+	"github.com/Kavirubc/wso2-amp-cli/internal/secrets"
+	"github.com/Kavirubc/wso2-amp-cli/internal/ui"
+)
+
+// loadSecretPolicy builds the secret-detection policy from the user's configuration
+func loadSecretPolicy() (*secrets.Policy, error) {
+	include := config.GetSecretIncludePatterns()
+	if err := secrets.ValidatePatterns(include); err != nil {
+		return nil, fmt.Errorf("%w. Fix it with: amp config set %s <patterns>", err, config.KeySecretInclude)
+	}
+	exclude := config.GetSecretExcludePatterns()
+	if err := secrets.ValidatePatterns(exclude); err != nil {
+		return nil, fmt.Errorf("%w. Fix it with: amp config set %s <patterns>", err, config.KeySecretExclude)
+	}
+	threshold, err := config.GetSecretEntropyThreshold()
+	if err != nil {
+		return nil, fmt.Errorf("%w. Fix it with: amp config set %s <bits>", err, config.KeySecretEntropy)
+	}
+	return secrets.NewPolicy(include, exclude, threshold)
+}
+
+// maskEnvironmentVariables returns a copy of vars with sensitive values masked
+func maskEnvironmentVariables(policy *secrets.Policy, vars []api.EnvironmentVariable) []api.EnvironmentVariable {
+	masked := make([]api.EnvironmentVariable, len(vars))
+	for i, v := range vars {
+		masked[i] = api.EnvironmentVariable{Key: v.Key, Value: v.Value}
+		if policy.IsSensitive(v.Key, v.Value) {
+			masked[i].Value = secrets.Mask(v.Value)
+		}
+	}
+	return masked
+}
+
+// hasAnySensitiveKey checks if any configuration is sensitive under the policy
+func hasAnySensitiveKey(policy *secrets.Policy, configs []api.EnvironmentVariable) bool {
+	for _, cfg := range configs {
+		if policy.IsSensitive(cfg.Key, cfg.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+// confirmShowSecrets asks for confirmation before revealing secrets of production
+// environments. It returns true when it is safe to proceed.
+func confirmShowSecrets(client *api.Client, org string, envNames []string, force bool) (bool, error) {
+	if force {
+		return true, nil
+	}
+
+	environments, _, err := client.ListEnvironments(org, api.ListOptions{Limit: 100})
+	if err != nil {
+		return false, fmt.Errorf("failed to check environments: %w", err)
+	}
+
+	var production []string
+	for _, env := range environments {
+		if !env.IsProduction {
+			continue
+		}
+		for _, name := range envNames {
+			if env.Name == name {
+				production = append(production, name)
+			}
+		}
+	}
+	if len(production) == 0 {
+		return true, nil
+	}
+
+	// Prompt on stderr so JSON output on stdout stays clean
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, ui.WarningStyle.Render("⚠️  You are about to reveal secrets of a production environment"))
+	fmt.Fprintf(os.Stderr, "   Environment: %s\n", strings.Join(production, ", "))
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprint(os.Stderr, "Are you sure? [y/N]: ")
+	reader := bufio.NewReader(os.Stdin)
+	response, _ := reader.ReadString('\n')
+	response = strings.TrimSpace(strings.ToLower(response))
+	if response != "y" && response != "yes" {
+		fmt.Fprintln(os.Stderr, ui.RenderWarning("Cancelled. Secrets were not shown."))
+		return false, nil
+	}
+	fmt.Fprintln(os.Stderr)
+	return true, nil
+}
@@ -56,7 +56,16 @@ func (c *Client) GetDeploymentsMap(orgName, projectName, agentName string) (map[
 
 // DeployAgent deploys an agent to an environment
 func (c *Client) DeployAgent(orgName, projectName, agentName string, req DeployAgentRequest) error {
+	return c.DeployAgentToEnvironment(orgName, projectName, agentName, "", req)
+}
+
+// DeployAgentToEnvironment deploys an agent to the named environment, or to
+// the pipeline's first environment when environment is empty
+func (c *Client) DeployAgentToEnvironment(orgName, projectName, agentName, environment string, req DeployAgentRequest) error {
 	path := "/orgs/" + orgName + "/projects/" + projectName + "/agents/" + agentName + "/deployments"
+	if environment != "" {
+		path += "?environment=" + url.QueryEscape(environment)
+	}
 
 	resp, err := c.doRequestWithBody("POST", path, req)
 	if err != nil {
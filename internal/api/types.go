@@ -78,6 +78,7 @@ type BuildResponse struct {
 	CommitID    string     `json:"commitId"`
 	Status      string     `json:"status"`
 	Branch      string     `json:"branch,omitempty"`
+	ImageID     string     `json:"imageId,omitempty"`
 	StartedAt   time.Time  `json:"startedAt"`
 	EndedAt     *time.Time `json:"endedAt,omitempty"`
 }
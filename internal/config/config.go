@@ -1,9 +1,13 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 
+	"github.com/Kavirubc/wso2-amp-cli/internal/secrets"
 	"github.com/spf13/viper"
 )
 
@@ -13,6 +17,10 @@ const (
 	KeyAPIKeyValue  = "api_key"
 	KeyDefaultOrg   = "default_org"
 	KeyDefaultProj  = "default_project"
+
+	KeySecretInclude = "secret_include"
+	KeySecretExclude = "secret_exclude"
+	KeySecretEntropy = "secret_entropy"
 )
 
 //ConfigDir returns the path to .amp
@@ -40,6 +48,7 @@ func Init() error {
 	viper.SetDefault(KeyAPIKeyValue, "")
 	viper.SetDefault(KeyDefaultOrg, "")
 	viper.SetDefault(KeyDefaultProj, "")
+	viper.SetDefault(KeySecretEntropy, strconv.FormatFloat(secrets.DefaultEntropyThreshold, 'f', 1, 64))
 	// Try to read existing config (ignore error if file doesn't exist yet)
 	_ = viper.ReadInConfig()
 	return nil
@@ -65,6 +74,58 @@ func GetAPIKeyValue() string    { return viper.GetString(KeyAPIKeyValue) }
 func GetDefaultOrg() string     { return viper.GetString(KeyDefaultOrg) }
 func GetDefaultProject() string { return viper.GetString(KeyDefaultProj) }
 
+// GetSecretIncludePatterns returns user regexes for keys that must be masked
+func GetSecretIncludePatterns() []string { return getPatternList(KeySecretInclude) }
+
+// GetSecretExcludePatterns returns user regexes for keys that must never be masked
+func GetSecretExcludePatterns() []string { return getPatternList(KeySecretExclude) }
+
+// GetSecretEntropyThreshold returns the entropy threshold for value-based detection (0 disables it)
+func GetSecretEntropyThreshold() (float64, error) {
+	return ParseEntropyThreshold(viper.GetString(KeySecretEntropy))
+}
+
+// ParseEntropyThreshold parses a secret_entropy value
+func ParseEntropyThreshold(value string) (float64, error) {
+	v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("invalid %s %q: must be a number of bits per character, or 0 to disable", KeySecretEntropy, value)
+	}
+	return v, nil
+}
+
+// SplitPatterns splits a string of secret patterns, one per line. Commas are
+// left alone since they are part of regexes such as \d{2,3}.
+func SplitPatterns(value string) []string {
+	var patterns []string
+	for _, line := range strings.Split(value, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			patterns = append(patterns, line)
+		}
+	}
+	return patterns
+}
+
+// getPatternList reads a key that is either a YAML list or a string with one pattern per line
+func getPatternList(key string) []string {
+	switch v := viper.Get(key).(type) {
+	case []interface{}:
+		patterns := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				patterns = append(patterns, s)
+			}
+		}
+		return patterns
+	case []string:
+		return v
+	case string:
+		return SplitPatterns(v)
+	default:
+		return nil
+	}
+}
+
 // ClearCredentials removes stored authentication credentials
 func ClearCredentials() error {
 	viper.Set(KeyAPIKeyValue, "")
@@ -75,6 +136,11 @@ func ClearCredentials() error {
 		return err
 	}
 
+	// Cached agent tokens go with the credentials that minted them
+	if err := os.Remove(TokenCacheFile()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
 	return viper.WriteConfigAs(ConfigFile())
 }
 
@@ -0,0 +1,117 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// maxDeploymentRecords is how many records the history keeps; older ones are dropped
+const maxDeploymentRecords = 2000
+
+// DeploymentRecord is one deployment made from this machine
+type DeploymentRecord struct {
+	Time        time.Time `json:"time"`
+	Server      string    `json:"server"`
+	Org         string    `json:"org"`
+	Project     string    `json:"project"`
+	Agent       string    `json:"agent"`
+	Environment string    `json:"environment"`
+	ImageID     string    `json:"imageId"`
+	Build       string    `json:"build,omitempty"`
+	CommitID    string    `json:"commitId,omitempty"`
+	User        string    `json:"user,omitempty"`
+	// Command is what made the deployment: deploy, promote or rollback
+	Command string `json:"command"`
+}
+
+// DeploymentHistoryFile returns the path to the local deployment history
+func DeploymentHistoryFile() string {
+	return filepath.Join(ConfigDir(), "deployments.jsonl")
+}
+
+// readDeploymentRecords reads every record, skipping lines that do not parse
+func readDeploymentRecords() ([]DeploymentRecord, error) {
+	f, err := os.Open(DeploymentHistoryFile())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []DeploymentRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var rec DeploymentRecord
+		if json.Unmarshal(scanner.Bytes(), &rec) == nil {
+			records = append(records, rec)
+		}
+	}
+	return records, scanner.Err()
+}
+
+// RecordDeployment appends a deployment to the history. The API server is
+// recorded so that histories of different servers do not mix.
+func RecordDeployment(rec DeploymentRecord) error {
+	if rec.Time.IsZero() {
+		rec.Time = time.Now().UTC()
+	}
+	rec.Server = GetAPIURL()
+
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(ConfigDir(), 0755); err != nil {
+		return err
+	}
+
+	records, err := readDeploymentRecords()
+	if err != nil {
+		return err
+	}
+	if len(records) < maxDeploymentRecords {
+		f, err := os.OpenFile(DeploymentHistoryFile(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = f.Write(append(line, '\n'))
+		return err
+	}
+
+	// Rewrite the file without the oldest records
+	var buf bytes.Buffer
+	for _, r := range records[len(records)-maxDeploymentRecords+1:] {
+		data, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		buf.Write(append(data, '\n'))
+	}
+	buf.Write(append(line, '\n'))
+	return os.WriteFile(DeploymentHistoryFile(), buf.Bytes(), 0644)
+}
+
+// DeploymentHistory returns the recorded deployments of an agent to an
+// environment on the current API server, oldest first
+func DeploymentHistory(org, project, agent, environment string) ([]DeploymentRecord, error) {
+	records, err := readDeploymentRecords()
+	if err != nil {
+		return nil, err
+	}
+	server := GetAPIURL()
+	var matched []DeploymentRecord
+	for _, rec := range records {
+		if rec.Server == server && rec.Org == org && rec.Project == project &&
+			rec.Agent == agent && rec.Environment == environment {
+			matched = append(matched, rec)
+		}
+	}
+	return matched, nil
+}
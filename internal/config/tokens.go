@@ -0,0 +1,69 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// CachedToken is an agent token kept between invocations
+type CachedToken struct {
+	Token     string `json:"token"`
+	TokenType string `json:"tokenType,omitempty"`
+	ExpiresAt int64  `json:"expiresAt"`
+}
+
+// TokenCacheFile returns the path to the agent token cache
+func TokenCacheFile() string {
+	return filepath.Join(ConfigDir(), "tokens.json")
+}
+
+// tokenCacheKey identifies an agent on a given API server
+func tokenCacheKey(org, project, agent string) string {
+	return GetAPIURL() + "|" + org + "/" + project + "/" + agent
+}
+
+func readTokenCache() map[string]CachedToken {
+	cache := make(map[string]CachedToken)
+	data, err := os.ReadFile(TokenCacheFile())
+	if err != nil {
+		return cache
+	}
+	_ = json.Unmarshal(data, &cache)
+	return cache
+}
+
+// GetCachedAgentToken returns a cached token that is still valid for at least minValidity
+func GetCachedAgentToken(org, project, agent string, minValidity time.Duration) (CachedToken, bool) {
+	token, ok := readTokenCache()[tokenCacheKey(org, project, agent)]
+	if !ok || token.Token == "" {
+		return CachedToken{}, false
+	}
+	if time.Unix(token.ExpiresAt, 0).Before(time.Now().Add(minValidity)) {
+		return CachedToken{}, false
+	}
+	return token, true
+}
+
+// SaveAgentToken stores a token in the cache, dropping expired entries
+func SaveAgentToken(org, project, agent string, token CachedToken) error {
+	cache := readTokenCache()
+	now := time.Now().Unix()
+	for key, t := range cache {
+		if t.ExpiresAt <= now {
+			delete(cache, key)
+		}
+	}
+	cache[tokenCacheKey(org, project, agent)] = token
+
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(ConfigDir(), 0755); err != nil {
+		return err
+	}
+	// Tokens are credentials, so only the owner may read the file
+	return os.WriteFile(TokenCacheFile(), data, 0600)
+}
@@ -0,0 +1,460 @@
+package gitrepo
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Object kinds
+const (
+	objCommit = "commit"
+	objTree   = "tree"
+	objBlob   = "blob"
+	objTag    = "tag"
+)
+
+// Pack entry types
+const (
+	packCommit   = 1
+	packTree     = 2
+	packBlob     = 3
+	packTag      = 4
+	packOfsDelta = 6
+	packRefDelta = 7
+)
+
+// maxDeltaDepth bounds delta chains, which git itself keeps to 50 by default
+const maxDeltaDepth = 100
+
+var errObjectNotFound = errors.New("object not found")
+
+// object is a decompressed git object
+type object struct {
+	kind string
+	data []byte
+}
+
+// packFile is a pack and its v2 index loaded into memory
+type packFile struct {
+	path    string
+	shas    []byte // sorted 20-byte SHAs
+	offsets []uint64
+}
+
+// count is the number of objects in the pack
+func (p *packFile) count() int {
+	return len(p.offsets)
+}
+
+// sha returns the i-th SHA of the index in hex
+func (p *packFile) sha(i int) string {
+	return hex.EncodeToString(p.shas[i*20 : i*20+20])
+}
+
+// find returns the pack offset of an object
+func (p *packFile) find(sha []byte) (uint64, bool) {
+	i := sort.Search(p.count(), func(i int) bool {
+		return bytes.Compare(p.shas[i*20:i*20+20], sha) >= 0
+	})
+	if i < p.count() && bytes.Equal(p.shas[i*20:i*20+20], sha) {
+		return p.offsets[i], true
+	}
+	return 0, false
+}
+
+// loadPacks reads the index of every pack once
+func (r *Repo) loadPacks() ([]*packFile, error) {
+	r.packsOnce.Do(func() {
+		paths, err := filepath.Glob(filepath.Join(r.commonDir, "objects", "pack", "*.idx"))
+		if err != nil {
+			r.packsErr = err
+			return
+		}
+		for _, path := range paths {
+			p, err := readPackIndex(path)
+			if err != nil {
+				r.packsErr = fmt.Errorf("%s: %w", filepath.Base(path), err)
+				return
+			}
+			r.packs = append(r.packs, p)
+		}
+	})
+	return r.packs, r.packsErr
+}
+
+// readPackIndex parses a version 2 pack index
+func readPackIndex(path string) (*packFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 8+256*4 || !bytes.Equal(data[:4], []byte{0xff, 't', 'O', 'c'}) {
+		return nil, fmt.Errorf("unsupported pack index format")
+	}
+	if v := binary.BigEndian.Uint32(data[4:8]); v != 2 {
+		return nil, fmt.Errorf("unsupported pack index version %d", v)
+	}
+
+	n := int(binary.BigEndian.Uint32(data[8+255*4:]))
+	shaStart := 8 + 256*4
+	offStart := shaStart + n*20 + n*4
+	largeStart := offStart + n*4
+	if len(data) < largeStart {
+		return nil, fmt.Errorf("truncated pack index")
+	}
+
+	p := &packFile{
+		path:    strings.TrimSuffix(path, ".idx") + ".pack",
+		shas:    data[shaStart : shaStart+n*20],
+		offsets: make([]uint64, n),
+	}
+	for i := 0; i < n; i++ {
+		off := binary.BigEndian.Uint32(data[offStart+i*4:])
+		if off&0x80000000 == 0 {
+			p.offsets[i] = uint64(off)
+			continue
+		}
+		// The high bit marks an index into the table of 64-bit offsets
+		pos := largeStart + int(off&0x7fffffff)*8
+		if len(data) < pos+8 {
+			return nil, fmt.Errorf("truncated pack index")
+		}
+		p.offsets[i] = binary.BigEndian.Uint64(data[pos:])
+	}
+	return p, nil
+}
+
+// findObjects returns the SHAs of loose and packed objects starting with prefix
+func (r *Repo) findObjects(prefix string) ([]string, error) {
+	found := make(map[string]bool)
+
+	if len(prefix) >= 2 {
+		dir := filepath.Join(r.commonDir, "objects", prefix[:2])
+		entries, err := os.ReadDir(dir)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		for _, e := range entries {
+			if sha := prefix[:2] + e.Name(); len(sha) == 40 && strings.HasPrefix(sha, prefix) {
+				found[sha] = true
+			}
+		}
+	}
+
+	packs, err := r.loadPacks()
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range packs {
+		i := sort.Search(p.count(), func(i int) bool { return p.sha(i) >= prefix })
+		for ; i < p.count() && strings.HasPrefix(p.sha(i), prefix); i++ {
+			found[p.sha(i)] = true
+		}
+	}
+
+	shas := make([]string, 0, len(found))
+	for sha := range found {
+		shas = append(shas, sha)
+	}
+	sort.Strings(shas)
+	return shas, nil
+}
+
+// readObject reads an object by its full SHA from loose objects or packs
+func (r *Repo) readObject(sha string) (*object, error) {
+	raw, err := hex.DecodeString(sha)
+	if err != nil || len(raw) != 20 {
+		return nil, fmt.Errorf("invalid object id %q", sha)
+	}
+
+	obj, err := r.readLooseObject(sha)
+	if err == nil || !errors.Is(err, errObjectNotFound) {
+		return obj, err
+	}
+	return r.readPackedObject(raw, 0)
+}
+
+// readLooseObject reads objects/xx/yyyy
+func (r *Repo) readLooseObject(sha string) (*object, error) {
+	f, err := os.Open(filepath.Join(r.commonDir, "objects", sha[:2], sha[2:]))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%s: %w", sha, errObjectNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	zr, err := zlib.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("object %s: %w", sha, err)
+	}
+	defer zr.Close()
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, fmt.Errorf("object %s: %w", sha, err)
+	}
+
+	header, body, ok := bytes.Cut(data, []byte{0})
+	if !ok {
+		return nil, fmt.Errorf("object %s: missing header", sha)
+	}
+	kind, _, _ := strings.Cut(string(header), " ")
+	return &object{kind: kind, data: body}, nil
+}
+
+// readPackedObject finds an object in the packs and resolves its deltas
+func (r *Repo) readPackedObject(sha []byte, depth int) (*object, error) {
+	packs, err := r.loadPacks()
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range packs {
+		if offset, ok := p.find(sha); ok {
+			return r.readPackEntry(p, offset, depth)
+		}
+	}
+	return nil, fmt.Errorf("%x: %w", sha, errObjectNotFound)
+}
+
+// readPackEntry reads the entry at offset in a pack
+func (r *Repo) readPackEntry(p *packFile, offset uint64, depth int) (*object, error) {
+	if depth > maxDeltaDepth {
+		return nil, fmt.Errorf("delta chain too deep in %s", filepath.Base(p.path))
+	}
+	f, err := os.Open(p.path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	br := bufio.NewReader(io.NewSectionReader(f, int64(offset), 1<<62))
+	c, err := br.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	kind := int(c>>4) & 7
+	for c&0x80 != 0 {
+		// Continuation bytes of the inflated size, which zlib tells us anyway
+		if c, err = br.ReadByte(); err != nil {
+			return nil, err
+		}
+	}
+
+	var base *object
+	switch kind {
+	case packOfsDelta:
+		c, err := br.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		back := uint64(c & 0x7f)
+		for c&0x80 != 0 {
+			if c, err = br.ReadByte(); err != nil {
+				return nil, err
+			}
+			back = (back+1)<<7 | uint64(c&0x7f)
+		}
+		if back > offset {
+			return nil, fmt.Errorf("invalid delta offset in %s", filepath.Base(p.path))
+		}
+		base, err = r.readPackEntry(p, offset-back, depth+1)
+		if err != nil {
+			return nil, err
+		}
+	case packRefDelta:
+		baseSHA := make([]byte, 20)
+		if _, err := io.ReadFull(br, baseSHA); err != nil {
+			return nil, err
+		}
+		base, err = r.readPackedObject(baseSHA, depth+1)
+		if errors.Is(err, errObjectNotFound) {
+			// Thin packs may delta against a loose object
+			base, err = r.readLooseObject(hex.EncodeToString(baseSHA))
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	zr, err := zlib.NewReader(br)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, err
+	}
+
+	if base != nil {
+		data, err = applyDelta(base.data, data)
+		if err != nil {
+			return nil, err
+		}
+		return &object{kind: base.kind, data: data}, nil
+	}
+
+	switch kind {
+	case packCommit:
+		return &object{kind: objCommit, data: data}, nil
+	case packTree:
+		return &object{kind: objTree, data: data}, nil
+	case packBlob:
+		return &object{kind: objBlob, data: data}, nil
+	case packTag:
+		return &object{kind: objTag, data: data}, nil
+	}
+	return nil, fmt.Errorf("unknown pack entry type %d", kind)
+}
+
+// applyDelta rebuilds an object from its base and a git delta
+func applyDelta(base, delta []byte) ([]byte, error) {
+	errCorrupt := errors.New("corrupt delta")
+	pos := 0
+	readSize := func() (uint64, error) {
+		var size uint64
+		for shift := uint(0); ; shift += 7 {
+			if pos >= len(delta) {
+				return 0, errCorrupt
+			}
+			c := delta[pos]
+			pos++
+			size |= uint64(c&0x7f) << shift
+			if c&0x80 == 0 {
+				return size, nil
+			}
+		}
+	}
+
+	srcSize, err := readSize()
+	if err != nil {
+		return nil, err
+	}
+	if srcSize != uint64(len(base)) {
+		return nil, errCorrupt
+	}
+	dstSize, err := readSize()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, dstSize)
+	for pos < len(delta) {
+		op := delta[pos]
+		pos++
+		switch {
+		case op&0x80 != 0:
+			// Copy from base: bits 0-3 select offset bytes, bits 4-6 size bytes
+			var offset, size uint64
+			for i := uint(0); i < 7; i++ {
+				if op&(1<<i) == 0 {
+					continue
+				}
+				if pos >= len(delta) {
+					return nil, errCorrupt
+				}
+				if i < 4 {
+					offset |= uint64(delta[pos]) << (8 * i)
+				} else {
+					size |= uint64(delta[pos]) << (8 * (i - 4))
+				}
+				pos++
+			}
+			if size == 0 {
+				size = 0x10000
+			}
+			if offset+size > uint64(len(base)) {
+				return nil, errCorrupt
+			}
+			out = append(out, base[offset:offset+size]...)
+		case op != 0:
+			// Insert the next op bytes literally
+			if pos+int(op) > len(delta) {
+				return nil, errCorrupt
+			}
+			out = append(out, delta[pos:pos+int(op)]...)
+			pos += int(op)
+		default:
+			return nil, errCorrupt
+		}
+	}
+	if uint64(len(out)) != dstSize {
+		return nil, errCorrupt
+	}
+	return out, nil
+}
+
+// headerField returns the first value of a header line in a commit or tag
+func headerField(data []byte, key string) string {
+	values := headerFields(data, key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// headerFields returns every value of a header line, e.g. all parents
+func headerFields(data []byte, key string) []string {
+	var values []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if line == "" {
+			// Headers end at the first blank line
+			break
+		}
+		if value, ok := strings.CutPrefix(line, key+" "); ok {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// treeEntry is one entry of a tree object
+type treeEntry struct {
+	mode uint32
+	name string
+	sha  string
+}
+
+// parseTree splits a tree object into its entries
+func parseTree(data []byte) ([]treeEntry, error) {
+	var entries []treeEntry
+	for len(data) > 0 {
+		sp := bytes.IndexByte(data, ' ')
+		nul := bytes.IndexByte(data, 0)
+		if sp < 0 || nul < sp || len(data) < nul+21 {
+			return nil, fmt.Errorf("corrupt tree")
+		}
+		mode, err := strconv.ParseUint(string(data[:sp]), 8, 32)
+		if err != nil {
+			return nil, fmt.Errorf("corrupt tree: %w", err)
+		}
+		entries = append(entries, treeEntry{
+			mode: uint32(mode),
+			name: string(data[sp+1 : nul]),
+			sha:  hex.EncodeToString(data[nul+1 : nul+21]),
+		})
+		data = data[nul+21:]
+	}
+	return entries, nil
+}
+
+// hashBlob computes the object ID git gives content stored as a blob
+func hashBlob(content []byte) string {
+	h := sha1.New()
+	fmt.Fprintf(h, "%s %d\x00", objBlob, len(content))
+	h.Write(content)
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,380 @@
+// Package gitrepo reads a local git repository directly from its .git
+// directory: refs, objects, the index and remotes. It never runs git and
+// never touches the network.
+package gitrepo
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrNotRepository is returned when no .git is found above a directory
+var ErrNotRepository = errors.New("not a git repository")
+
+// maxSymrefDepth bounds how many symbolic refs are followed
+const maxSymrefDepth = 5
+
+var (
+	hexPattern = regexp.MustCompile(`^[0-9a-fA-F]+$`)
+	// ancestrySuffix matches trailing ~N and ^N navigation, e.g. HEAD~2^2
+	ancestrySuffix = regexp.MustCompile(`([~^][0-9]*)+$`)
+	ancestryStep   = regexp.MustCompile(`[~^][0-9]*`)
+)
+
+// Repo is a git repository on disk
+type Repo struct {
+	// WorkTree is the checked out directory
+	WorkTree string
+	// GitDir holds HEAD and the index; for a linked worktree it differs from commonDir
+	GitDir    string
+	commonDir string
+
+	packsOnce sync.Once
+	packs     []*packFile
+	packsErr  error
+}
+
+// Revision is a resolved commit
+type Revision struct {
+	SHA string
+	// Ref is the full ref name the revision was resolved through, if any
+	Ref string
+}
+
+// Remote is a configured remote
+type Remote struct {
+	Name string
+	URL  string
+}
+
+// Open finds the repository containing dir, looking in parent directories
+func Open(dir string) (*Repo, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		dotGit := filepath.Join(dir, ".git")
+		if fi, err := os.Stat(dotGit); err == nil {
+			if fi.IsDir() {
+				return newRepo(dotGit, dir), nil
+			}
+			// Worktrees and submodules have a .git file pointing at the git dir
+			data, err := os.ReadFile(dotGit)
+			if err != nil {
+				return nil, err
+			}
+			line := strings.TrimSpace(string(data))
+			if !strings.HasPrefix(line, "gitdir:") {
+				return nil, fmt.Errorf("invalid .git file in %s", dir)
+			}
+			gitDir := strings.TrimSpace(strings.TrimPrefix(line, "gitdir:"))
+			if !filepath.IsAbs(gitDir) {
+				gitDir = filepath.Join(dir, gitDir)
+			}
+			return newRepo(gitDir, dir), nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, ErrNotRepository
+		}
+		dir = parent
+	}
+}
+
+func newRepo(gitDir, workTree string) *Repo {
+	r := &Repo{WorkTree: workTree, GitDir: gitDir, commonDir: gitDir}
+	if data, err := os.ReadFile(filepath.Join(gitDir, "commondir")); err == nil {
+		common := strings.TrimSpace(string(data))
+		if !filepath.IsAbs(common) {
+			common = filepath.Join(gitDir, common)
+		}
+		r.commonDir = filepath.Clean(common)
+	}
+	return r
+}
+
+// IsHex reports whether s consists only of hexadecimal digits
+func IsHex(s string) bool {
+	return s != "" && hexPattern.MatchString(s)
+}
+
+// ReadRef resolves a full ref name such as HEAD or refs/heads/main to a SHA,
+// following symbolic refs
+func (r *Repo) ReadRef(name string) (string, error) {
+	for depth := 0; depth < maxSymrefDepth; depth++ {
+		value, err := r.readRefValue(name)
+		if err != nil {
+			return "", err
+		}
+		if !strings.HasPrefix(value, "ref:") {
+			return value, nil
+		}
+		name = strings.TrimSpace(strings.TrimPrefix(value, "ref:"))
+	}
+	return "", fmt.Errorf("too many levels of symbolic refs at %s", name)
+}
+
+// readRefValue reads one ref without following it: a SHA or "ref: <target>"
+func (r *Repo) readRefValue(name string) (string, error) {
+	// HEAD and other pseudo refs are per worktree; refs/ are shared
+	dirs := []string{r.commonDir}
+	if !strings.HasPrefix(name, "refs/") {
+		dirs = []string{r.GitDir, r.commonDir}
+	}
+	for _, dir := range dirs {
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+		if err == nil {
+			return strings.TrimSpace(string(data)), nil
+		}
+	}
+
+	packed, err := r.packedRefs()
+	if err != nil {
+		return "", err
+	}
+	if sha, ok := packed[name]; ok {
+		return sha, nil
+	}
+	return "", fmt.Errorf("ref %s not found", name)
+}
+
+// packedRefs parses .git/packed-refs
+func (r *Repo) packedRefs() (map[string]string, error) {
+	refs := make(map[string]string)
+	f, err := os.Open(filepath.Join(r.commonDir, "packed-refs"))
+	if os.IsNotExist(err) {
+		return refs, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		// Comments and peeled tag lines
+		if line == "" || line[0] == '#' || line[0] == '^' {
+			continue
+		}
+		if sha, name, ok := strings.Cut(line, " "); ok {
+			refs[name] = sha
+		}
+	}
+	return refs, scanner.Err()
+}
+
+// CurrentBranch returns the short name of the checked out branch, or "" when
+// HEAD is detached
+func (r *Repo) CurrentBranch() string {
+	value, err := r.readRefValue("HEAD")
+	if err != nil || !strings.HasPrefix(value, "ref:") {
+		return ""
+	}
+	return strings.TrimPrefix(strings.TrimSpace(strings.TrimPrefix(value, "ref:")), "refs/heads/")
+}
+
+// Resolve turns HEAD, a branch, tag or remote branch name, or a full or
+// abbreviated SHA into a commit. Refs are tried in git's order, so a branch
+// shadows a SHA prefix with the same spelling. A trailing ~N selects the Nth
+// first-parent ancestor and ^N the Nth parent, as in git.
+func (r *Repo) Resolve(rev string) (Revision, error) {
+	if rev == "" {
+		return Revision{}, fmt.Errorf("empty revision")
+	}
+	if suffix := ancestrySuffix.FindString(rev); suffix != "" && suffix != rev {
+		base, err := r.Resolve(strings.TrimSuffix(rev, suffix))
+		if err != nil {
+			return Revision{}, err
+		}
+		sha, err := r.walkAncestry(base.SHA, suffix)
+		if err != nil {
+			return Revision{}, fmt.Errorf("%s: %w", rev, err)
+		}
+		return Revision{SHA: sha}, nil
+	}
+	if rev == "@" {
+		rev = "HEAD"
+	}
+
+	candidates := []string{rev, "refs/" + rev, "refs/tags/" + rev, "refs/heads/" + rev, "refs/remotes/" + rev, "refs/remotes/" + rev + "/HEAD"}
+	if strings.HasPrefix(rev, "refs/") {
+		candidates = []string{rev}
+	}
+	for _, ref := range candidates {
+		// Only all-caps names like HEAD are looked up outside refs/
+		if !strings.HasPrefix(ref, "refs/") && ref != strings.ToUpper(ref) {
+			continue
+		}
+		sha, err := r.ReadRef(ref)
+		if err != nil {
+			continue
+		}
+		commit, err := r.peelToCommit(sha)
+		if err != nil {
+			return Revision{}, fmt.Errorf("%s: %w", rev, err)
+		}
+		return Revision{SHA: commit, Ref: ref}, nil
+	}
+
+	if !IsHex(rev) || len(rev) < 4 || len(rev) > 40 {
+		return Revision{}, fmt.Errorf("unknown revision %q", rev)
+	}
+	matches, err := r.findObjects(strings.ToLower(rev))
+	if err != nil {
+		return Revision{}, err
+	}
+	var commits []string
+	for _, sha := range matches {
+		if commit, err := r.peelToCommit(sha); err == nil && commit == sha {
+			commits = append(commits, sha)
+		}
+	}
+	switch len(commits) {
+	case 0:
+		return Revision{}, fmt.Errorf("unknown revision %q", rev)
+	case 1:
+		return Revision{SHA: commits[0]}, nil
+	}
+	sort.Strings(commits)
+	return Revision{}, fmt.Errorf("short SHA %s is ambiguous: %s", rev, strings.Join(commits, ", "))
+}
+
+// walkAncestry applies ~N and ^N steps to a commit
+func (r *Repo) walkAncestry(sha, suffix string) (string, error) {
+	for _, step := range ancestryStep.FindAllString(suffix, -1) {
+		n := 1
+		if len(step) > 1 {
+			n, _ = strconv.Atoi(step[1:])
+		}
+		// ~N follows the first parent N times; ^N picks the Nth parent once
+		hops, parent := n, 1
+		if step[0] == '^' {
+			hops, parent = 1, n
+		}
+		if parent == 0 {
+			// ^0 is the commit itself
+			continue
+		}
+		for i := 0; i < hops; i++ {
+			commit, err := r.readObject(sha)
+			if err != nil {
+				return "", err
+			}
+			parents := headerFields(commit.data, "parent")
+			if len(parents) < parent {
+				return "", fmt.Errorf("commit %s has no parent %d", sha[:12], parent)
+			}
+			sha = parents[parent-1]
+		}
+	}
+	return sha, nil
+}
+
+// peelToCommit follows annotated tags to the commit they point at
+func (r *Repo) peelToCommit(sha string) (string, error) {
+	for depth := 0; depth < maxSymrefDepth; depth++ {
+		obj, err := r.readObject(sha)
+		if err != nil {
+			return "", err
+		}
+		switch obj.kind {
+		case objCommit:
+			return sha, nil
+		case objTag:
+			target := headerField(obj.data, "object")
+			if target == "" {
+				return "", fmt.Errorf("tag %s has no object", sha)
+			}
+			sha = target
+		default:
+			return "", fmt.Errorf("%s is a %s, not a commit", sha, obj.kind)
+		}
+	}
+	return "", fmt.Errorf("too many nested tags at %s", sha)
+}
+
+// Remotes lists the remotes in .git/config
+func (r *Repo) Remotes() ([]Remote, error) {
+	f, err := os.Open(filepath.Join(r.commonDir, "config"))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var remotes []Remote
+	current := -1
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			current = -1
+			section := strings.TrimSuffix(strings.TrimPrefix(line, "["), "]")
+			if name, ok := strings.CutPrefix(section, "remote "); ok {
+				remotes = append(remotes, Remote{Name: strings.Trim(name, `"`)})
+				current = len(remotes) - 1
+			}
+			continue
+		}
+		if current < 0 {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if ok && strings.EqualFold(strings.TrimSpace(key), "url") {
+			remotes[current].URL = strings.Trim(strings.TrimSpace(value), `"`)
+		}
+	}
+	return remotes, scanner.Err()
+}
+
+// RemoteForURL finds the remote whose URL points at the same repository as
+// url, ignoring the scheme, user, a trailing .git and letter case
+func (r *Repo) RemoteForURL(url string) (Remote, bool) {
+	remotes, err := r.Remotes()
+	if err != nil {
+		return Remote{}, false
+	}
+	want := NormalizeURL(url)
+	for _, remote := range remotes {
+		if NormalizeURL(remote.URL) == want {
+			return remote, true
+		}
+	}
+	return Remote{}, false
+}
+
+// NormalizeURL reduces a repository URL to host/path, so that the HTTPS and
+// SSH forms of the same repository compare equal
+func NormalizeURL(url string) string {
+	u := strings.TrimSpace(url)
+	if i := strings.Index(u, "://"); i >= 0 {
+		u = u[i+3:]
+	} else if host, path, ok := strings.Cut(u, ":"); ok && !strings.Contains(host, "/") {
+		// scp-like syntax: git@github.com:org/repo.git
+		u = host + "/" + path
+	}
+	if at := strings.LastIndex(u, "@"); at >= 0 && at < strings.Index(u+"/", "/") {
+		u = u[at+1:]
+	}
+	u = strings.TrimSuffix(strings.TrimSuffix(u, "/"), ".git")
+	// Drop an explicit port, which differs between protocols
+	if host, path, ok := strings.Cut(u, "/"); ok {
+		if h, _, ok := strings.Cut(host, ":"); ok {
+			host = h
+		}
+		u = host + "/" + path
+	}
+	return strings.ToLower(u)
+}
@@ -0,0 +1,378 @@
+package gitrepo
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// git runs the git CLI in dir with a configuration isolated from the machine
+func git(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	out, err := gitOutput(dir, args...)
+	if err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return strings.TrimSpace(out)
+}
+
+func gitOutput(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_CONFIG_NOSYSTEM=1",
+		"GIT_CONFIG_GLOBAL="+os.DevNull,
+		"GIT_AUTHOR_NAME=Test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=Test", "GIT_COMMITTER_EMAIL=test@example.com",
+	)
+	out, err := cmd.CombinedOutput()
+	return string(out), err
+}
+
+// writeFile writes a file in the work tree, creating its directories
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// largeFile returns content that changes a little per version, so packing
+// stores later versions as deltas
+func largeFile(version int) string {
+	var b strings.Builder
+	for i := 0; i < 300; i++ {
+		fmt.Fprintf(&b, "line %d of a file that packs well\n", i)
+		if i%50 == 0 {
+			fmt.Fprintf(&b, "version %d\n", version)
+		}
+	}
+	return b.String()
+}
+
+// newTestRepo builds a history with a merge, tags and a remote branch:
+//
+//	main:    c1 - c2 - c3 - c4 - merge
+//	                   \         /
+//	feature:            f1 - f2
+func newTestRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	git(t, dir, "init", "-q", "-b", "main")
+
+	commit := func(msg string) {
+		git(t, dir, "add", "-A")
+		git(t, dir, "commit", "-q", "-m", msg)
+	}
+	for i := 1; i <= 2; i++ {
+		writeFile(t, dir, "src/big.txt", largeFile(i))
+		writeFile(t, dir, fmt.Sprintf("src/pkg/file%d.go", i), fmt.Sprintf("package pkg // %d\n", i))
+		commit(fmt.Sprintf("c%d", i))
+	}
+	git(t, dir, "checkout", "-q", "-b", "feature")
+	for i := 1; i <= 2; i++ {
+		writeFile(t, dir, "feature.txt", largeFile(100+i))
+		commit(fmt.Sprintf("f%d", i))
+	}
+	git(t, dir, "checkout", "-q", "main")
+	for i := 3; i <= 4; i++ {
+		writeFile(t, dir, "src/big.txt", largeFile(i))
+		commit(fmt.Sprintf("c%d", i))
+	}
+	git(t, dir, "tag", "v1", "HEAD~1")
+	git(t, dir, "tag", "-a", "-m", "release", "v2")
+	git(t, dir, "merge", "-q", "--no-ff", "-m", "merge", "feature")
+
+	git(t, dir, "remote", "add", "origin", "git@github.com:example/repo.git")
+	git(t, dir, "update-ref", "refs/remotes/origin/main", "HEAD~1")
+	return dir
+}
+
+// storageModes rewrite the object store between checks: all loose objects,
+// packed with offset deltas, and packed with ref deltas
+var storageModes = []struct {
+	name   string
+	repack func(t *testing.T, dir string)
+}{
+	{"loose", func(t *testing.T, dir string) {}},
+	{"packed", func(t *testing.T, dir string) {
+		git(t, dir, "gc", "-q", "--prune=now")
+	}},
+	{"ref-delta", func(t *testing.T, dir string) {
+		git(t, dir, "-c", "repack.useDeltaBaseOffset=false", "repack", "-q", "-a", "-d", "-f")
+		git(t, dir, "prune-packed")
+		git(t, dir, "pack-refs", "--all")
+	}},
+}
+
+// testRevisions are resolved by both git and Resolve
+func testRevisions(t *testing.T, dir string) []string {
+	revs := []string{
+		"HEAD", "@", "main", "feature", "v1", "v2", "origin/main", "refs/heads/feature",
+		"HEAD~1", "HEAD~3", "HEAD^", "HEAD^2", "HEAD^2~1", "main~2^", "v2~1", "feature^1",
+	}
+	// Abbreviated SHAs of every commit
+	for _, sha := range strings.Fields(git(t, dir, "rev-list", "--all")) {
+		revs = append(revs, sha, sha[:7], strings.ToUpper(sha[:10]))
+	}
+	return revs
+}
+
+func TestResolveMatchesGit(t *testing.T) {
+	dir := newTestRepo(t)
+	for _, mode := range storageModes {
+		t.Run(mode.name, func(t *testing.T) {
+			mode.repack(t, dir)
+			repo, err := Open(filepath.Join(dir, "src", "pkg"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, rev := range testRevisions(t, dir) {
+				want := git(t, dir, "rev-parse", "--verify", rev+"^{commit}")
+				got, err := repo.Resolve(rev)
+				if err != nil {
+					t.Errorf("Resolve(%q): %v", rev, err)
+					continue
+				}
+				if got.SHA != want {
+					t.Errorf("Resolve(%q) = %s, git says %s", rev, got.SHA, want)
+				}
+			}
+			if _, err := repo.Resolve("no-such-branch"); err == nil {
+				t.Error("Resolve accepted an unknown revision")
+			}
+		})
+	}
+}
+
+func TestCurrentBranchAndDetachedHead(t *testing.T) {
+	dir := newTestRepo(t)
+	repo, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := repo.CurrentBranch(); got != "main" {
+		t.Errorf("CurrentBranch() = %q, want main", got)
+	}
+
+	git(t, dir, "checkout", "-q", "--detach", "v1")
+	repo, _ = Open(dir)
+	if got := repo.CurrentBranch(); got != "" {
+		t.Errorf("CurrentBranch() = %q on a detached HEAD, want none", got)
+	}
+	got, err := repo.Resolve("HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := git(t, dir, "rev-parse", "HEAD"); got.SHA != want {
+		t.Errorf("Resolve(HEAD) = %s, git says %s", got.SHA, want)
+	}
+}
+
+func TestIsAncestorMatchesGit(t *testing.T) {
+	dir := newTestRepo(t)
+	pairs := [][2]string{
+		{"main~4", "HEAD"}, {"HEAD", "main~4"}, {"feature", "main"}, {"main", "feature"},
+		{"main~1", "feature"}, {"feature~1", "main~1"}, {"v1", "v2"}, {"HEAD", "HEAD"},
+	}
+	for _, mode := range storageModes {
+		t.Run(mode.name, func(t *testing.T) {
+			mode.repack(t, dir)
+			repo, err := Open(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, pair := range pairs {
+				a, _ := repo.Resolve(pair[0])
+				d, _ := repo.Resolve(pair[1])
+				_, err := gitOutput(dir, "merge-base", "--is-ancestor", pair[0], pair[1])
+				want := err == nil
+
+				got, ok, err := repo.IsAncestor(a.SHA, d.SHA, 1000)
+				if err != nil || !ok {
+					t.Errorf("IsAncestor(%s, %s): ok=%v err=%v", pair[0], pair[1], ok, err)
+					continue
+				}
+				if got != want {
+					t.Errorf("IsAncestor(%s, %s) = %v, git says %v", pair[0], pair[1], got, want)
+				}
+			}
+
+			// A walk cut short gives no answer rather than a wrong one
+			root, _ := repo.Resolve("main~4")
+			head, _ := repo.Resolve("HEAD")
+			if _, ok, _ := repo.IsAncestor(root.SHA, head.SHA, 1); ok {
+				t.Error("IsAncestor answered although its limit was reached")
+			}
+		})
+	}
+}
+
+// gitStatus reads git status --porcelain into the shape of Status
+func gitStatus(t *testing.T, dir string) Status {
+	t.Helper()
+	// Leading spaces are part of the format, so the output is not trimmed
+	out, err := gitOutput(dir, "status", "--porcelain=v1", "-uno", "--no-renames")
+	if err != nil {
+		t.Fatalf("git status: %v\n%s", err, out)
+	}
+	var s Status
+	for _, line := range strings.Split(out, "\n") {
+		if len(line) < 4 {
+			continue
+		}
+		x, y, path := line[0], line[1], line[3:]
+		if x == 'U' || y == 'U' || (x == 'A' && y == 'A') || (x == 'D' && y == 'D') {
+			s.Conflicted = append(s.Conflicted, path)
+			continue
+		}
+		if x != ' ' {
+			s.Staged = append(s.Staged, path)
+		}
+		if y != ' ' {
+			s.Modified = append(s.Modified, path)
+		}
+	}
+	return s
+}
+
+func compareStatus(t *testing.T, dir string, repo *Repo) {
+	t.Helper()
+	got, err := repo.Status()
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	want := gitStatus(t, dir)
+	for _, c := range []struct {
+		name      string
+		got, want []string
+	}{
+		{"staged", got.Staged, want.Staged},
+		{"modified", got.Modified, want.Modified},
+		{"conflicted", got.Conflicted, want.Conflicted},
+	} {
+		sort.Strings(c.got)
+		sort.Strings(c.want)
+		if strings.Join(c.got, ",") != strings.Join(c.want, ",") {
+			t.Errorf("%s paths = %v, git says %v", c.name, c.got, c.want)
+		}
+	}
+	if got.Clean() != (len(want.Paths()) == 0) {
+		t.Errorf("Clean() = %v, git status lists %v", got.Clean(), want.Paths())
+	}
+}
+
+func TestStatusMatchesGit(t *testing.T) {
+	for _, mode := range storageModes {
+		t.Run(mode.name, func(t *testing.T) {
+			dir := newTestRepo(t)
+			mode.repack(t, dir)
+			repo, err := Open(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			compareStatus(t, dir, repo)
+
+			// Touching a file without changing it is not a change
+			future := time.Now().Add(time.Hour)
+			os.Chtimes(filepath.Join(dir, "src", "big.txt"), future, future)
+			compareStatus(t, dir, repo)
+
+			writeFile(t, dir, "src/big.txt", largeFile(99))
+			writeFile(t, dir, "src/pkg/file1.go", "package pkg // staged\n")
+			git(t, dir, "add", "src/pkg/file1.go")
+			writeFile(t, dir, "src/pkg/file3.go", "package pkg // new\n")
+			git(t, dir, "add", "src/pkg/file3.go")
+			os.Remove(filepath.Join(dir, "feature.txt"))
+			git(t, dir, "rm", "-q", "--cached", "src/pkg/file2.go")
+			writeFile(t, dir, "untracked.txt", "ignored by status\n")
+			compareStatus(t, dir, repo)
+
+			// The same state read from an index v4
+			git(t, dir, "update-index", "--index-version", "4")
+			compareStatus(t, dir, repo)
+		})
+	}
+}
+
+func TestStatusConflicts(t *testing.T) {
+	dir := newTestRepo(t)
+	git(t, dir, "checkout", "-q", "-b", "other", "HEAD~1")
+	writeFile(t, dir, "src/big.txt", largeFile(1000))
+	git(t, dir, "commit", "-q", "-am", "other")
+	git(t, dir, "checkout", "-q", "main")
+	writeFile(t, dir, "src/big.txt", largeFile(2000))
+	git(t, dir, "commit", "-q", "-am", "main")
+	if _, err := gitOutput(dir, "merge", "other"); err == nil {
+		t.Fatal("expected a merge conflict")
+	}
+
+	repo, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	compareStatus(t, dir, repo)
+}
+
+func TestLinkedWorktree(t *testing.T) {
+	dir := newTestRepo(t)
+	git(t, dir, "gc", "-q", "--prune=now")
+	wt := filepath.Join(t.TempDir(), "wt")
+	git(t, dir, "worktree", "add", "-q", "-b", "topic", wt, "v1")
+
+	repo, err := Open(wt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := repo.CurrentBranch(); got != "topic" {
+		t.Errorf("CurrentBranch() = %q, want topic", got)
+	}
+	for _, rev := range []string{"HEAD", "topic", "main", "v2", "origin/main", "HEAD~1"} {
+		got, err := repo.Resolve(rev)
+		if err != nil {
+			t.Errorf("Resolve(%q): %v", rev, err)
+			continue
+		}
+		if want := git(t, wt, "rev-parse", "--verify", rev+"^{commit}"); got.SHA != want {
+			t.Errorf("Resolve(%q) = %s, git says %s", rev, got.SHA, want)
+		}
+	}
+
+	compareStatus(t, wt, repo)
+	writeFile(t, wt, "src/big.txt", largeFile(42))
+	compareStatus(t, wt, repo)
+}
+
+func TestOpenOutsideRepository(t *testing.T) {
+	if _, err := Open(t.TempDir()); !errors.Is(err, ErrNotRepository) {
+		t.Errorf("Open outside a repository: %v, want ErrNotRepository", err)
+	}
+}
+
+func TestRemotes(t *testing.T) {
+	dir := newTestRepo(t)
+	repo, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	remote, ok := repo.RemoteForURL("https://github.com/Example/repo")
+	if !ok || remote.Name != "origin" {
+		t.Errorf("RemoteForURL = %+v, %v, want origin", remote, ok)
+	}
+	if _, ok := repo.RemoteForURL("https://github.com/example/other"); ok {
+		t.Error("RemoteForURL matched a different repository")
+	}
+}
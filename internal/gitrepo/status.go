@@ -0,0 +1,339 @@
+package gitrepo
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+)
+
+// File modes of index and tree entries
+const (
+	modeTypeMask = 0o170000
+	modeSymlink  = 0o120000
+	modeGitlink  = 0o160000
+)
+
+// Index entry flags
+const (
+	flagExtended     = 0x4000
+	flagStageMask    = 0x3000
+	flagNameMask     = 0x0fff
+	flagSkipWorktree = 0x4000 // extended flags
+	flagIntentToAdd  = 0x2000 // extended flags
+)
+
+// Status lists uncommitted changes to tracked files. Untracked files are not
+// reported, matching git describe --dirty.
+type Status struct {
+	// Staged are paths whose index entry differs from HEAD
+	Staged []string
+	// Modified are paths whose working tree file differs from the index
+	Modified []string
+	// Conflicted are paths with unresolved merge conflicts
+	Conflicted []string
+}
+
+// Clean reports whether the working tree matches HEAD
+func (s *Status) Clean() bool {
+	return len(s.Staged) == 0 && len(s.Modified) == 0 && len(s.Conflicted) == 0
+}
+
+// Paths returns every changed path once, sorted
+func (s *Status) Paths() []string {
+	seen := make(map[string]bool)
+	var paths []string
+	for _, list := range [][]string{s.Conflicted, s.Staged, s.Modified} {
+		for _, p := range list {
+			if !seen[p] {
+				seen[p] = true
+				paths = append(paths, p)
+			}
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// indexEntry is a file recorded in .git/index
+type indexEntry struct {
+	path      string
+	mode      uint32
+	sha       string
+	size      uint32
+	mtimeSec  uint32
+	mtimeNsec uint32
+	stage     int
+	skip      bool
+	intent    bool
+}
+
+// Status compares HEAD, the index and the working tree
+func (r *Repo) Status() (*Status, error) {
+	entries, err := r.readIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	head := make(map[string]treeEntry)
+	if sha, err := r.ReadRef("HEAD"); err == nil {
+		if err := r.flattenCommitTree(sha, head); err != nil {
+			return nil, err
+		}
+	}
+
+	status := &Status{}
+	inIndex := make(map[string]bool)
+	for _, e := range entries {
+		inIndex[e.path] = true
+		if e.stage != 0 {
+			if len(status.Conflicted) == 0 || status.Conflicted[len(status.Conflicted)-1] != e.path {
+				status.Conflicted = append(status.Conflicted, e.path)
+			}
+			continue
+		}
+		if e.intent {
+			status.Staged = append(status.Staged, e.path)
+			continue
+		}
+
+		if te, ok := head[e.path]; !ok || te.sha != e.sha || te.mode != e.mode {
+			status.Staged = append(status.Staged, e.path)
+		}
+		if e.skip || e.mode&modeTypeMask == modeGitlink {
+			continue
+		}
+		changed, err := r.worktreeChanged(e)
+		if err != nil {
+			return nil, err
+		}
+		if changed {
+			status.Modified = append(status.Modified, e.path)
+		}
+	}
+	for p := range head {
+		if !inIndex[p] {
+			// Removed with git rm
+			status.Staged = append(status.Staged, p)
+		}
+	}
+	sort.Strings(status.Staged)
+	return status, nil
+}
+
+// worktreeChanged reports whether the file on disk differs from its index entry
+func (r *Repo) worktreeChanged(e indexEntry) (bool, error) {
+	full := filepath.Join(r.WorkTree, filepath.FromSlash(e.path))
+	fi, err := os.Lstat(full)
+	if os.IsNotExist(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	isLink := fi.Mode()&os.ModeSymlink != 0
+	if isLink != (e.mode&modeTypeMask == modeSymlink) {
+		return true, nil
+	}
+	// The index keeps the low 32 bits of the size
+	if uint32(fi.Size()) != e.size {
+		return true, nil
+	}
+	// Same size and mtime as when it was staged: trust the index as git does
+	mtime := fi.ModTime()
+	if uint32(mtime.Unix()) == e.mtimeSec && uint32(mtime.Nanosecond()) == e.mtimeNsec {
+		return false, nil
+	}
+
+	var content []byte
+	if isLink {
+		target, err := os.Readlink(full)
+		if err != nil {
+			return false, err
+		}
+		content = []byte(target)
+	} else if content, err = os.ReadFile(full); err != nil {
+		return false, err
+	}
+	return hashBlob(content) != e.sha, nil
+}
+
+// flattenCommitTree maps every path in a commit's tree to its entry
+func (r *Repo) flattenCommitTree(commitSHA string, out map[string]treeEntry) error {
+	commit, err := r.readObject(commitSHA)
+	if err != nil {
+		return err
+	}
+	if commit.kind != objCommit {
+		return fmt.Errorf("%s is a %s, not a commit", commitSHA, commit.kind)
+	}
+	return r.flattenTree(headerField(commit.data, "tree"), "", out)
+}
+
+func (r *Repo) flattenTree(sha, prefix string, out map[string]treeEntry) error {
+	tree, err := r.readObject(sha)
+	if err != nil {
+		return err
+	}
+	if tree.kind != objTree {
+		return fmt.Errorf("%s is a %s, not a tree", sha, tree.kind)
+	}
+	entries, err := parseTree(tree.data)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		p := path.Join(prefix, e.name)
+		if e.mode == 0o40000 {
+			if err := r.flattenTree(e.sha, p, out); err != nil {
+				return err
+			}
+			continue
+		}
+		out[p] = e
+	}
+	return nil
+}
+
+// readIndex parses .git/index, versions 2 to 4
+func (r *Repo) readIndex() ([]indexEntry, error) {
+	data, err := os.ReadFile(filepath.Join(r.GitDir, "index"))
+	if os.IsNotExist(err) {
+		// Nothing staged yet
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 12+20 || string(data[:4]) != "DIRC" {
+		return nil, fmt.Errorf("invalid index file")
+	}
+	// index.skipHash writes a zero checksum
+	sum := sha1.Sum(data[:len(data)-20])
+	if trailer := data[len(data)-20:]; !bytes.Equal(sum[:], trailer) && !bytes.Equal(trailer, make([]byte, 20)) {
+		return nil, fmt.Errorf("index checksum mismatch")
+	}
+	version := binary.BigEndian.Uint32(data[4:8])
+	if version < 2 || version > 4 {
+		return nil, fmt.Errorf("unsupported index version %d", version)
+	}
+	count := int(binary.BigEndian.Uint32(data[8:12]))
+
+	entries := make([]indexEntry, 0, count)
+	pos := 12
+	prevName := ""
+	for i := 0; i < count; i++ {
+		start := pos
+		if len(data) < pos+62 {
+			return nil, fmt.Errorf("truncated index")
+		}
+		e := indexEntry{
+			mtimeSec:  binary.BigEndian.Uint32(data[pos+8:]),
+			mtimeNsec: binary.BigEndian.Uint32(data[pos+12:]),
+			mode:      binary.BigEndian.Uint32(data[pos+24:]),
+			size:      binary.BigEndian.Uint32(data[pos+36:]),
+			sha:       hex.EncodeToString(data[pos+40 : pos+60]),
+		}
+		flags := binary.BigEndian.Uint16(data[pos+60:])
+		e.stage = int(flags&flagStageMask) >> 12
+		pos += 62
+		if flags&flagExtended != 0 && version >= 3 {
+			if len(data) < pos+2 {
+				return nil, fmt.Errorf("truncated index")
+			}
+			extended := binary.BigEndian.Uint16(data[pos:])
+			e.skip = extended&flagSkipWorktree != 0
+			e.intent = extended&flagIntentToAdd != 0
+			pos += 2
+		}
+
+		if version == 4 {
+			// The name drops a number of bytes from the previous name, then adds a suffix
+			strip, n := readOffsetVarint(data[pos:])
+			if n == 0 || int(strip) > len(prevName) {
+				return nil, fmt.Errorf("corrupt index entry")
+			}
+			pos += n
+			nul := bytes.IndexByte(data[pos:], 0)
+			if nul < 0 {
+				return nil, fmt.Errorf("truncated index")
+			}
+			e.path = prevName[:len(prevName)-int(strip)] + string(data[pos:pos+nul])
+			pos += nul + 1
+		} else {
+			nameLen := int(flags & flagNameMask)
+			if nameLen == flagNameMask {
+				nameLen = bytes.IndexByte(data[pos:], 0)
+			}
+			if nameLen < 0 || len(data) < pos+nameLen {
+				return nil, fmt.Errorf("truncated index")
+			}
+			e.path = string(data[pos : pos+nameLen])
+			// Entries are NUL padded to a multiple of eight bytes
+			pos = start + (pos-start+nameLen+8)&^7
+		}
+		prevName = e.path
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// readOffsetVarint decodes the variable length integer used by index v4 and
+// pack offset deltas, returning the value and the bytes read
+func readOffsetVarint(b []byte) (uint64, int) {
+	if len(b) == 0 {
+		return 0, 0
+	}
+	c := b[0]
+	value := uint64(c & 0x7f)
+	n := 1
+	for c&0x80 != 0 {
+		if n >= len(b) {
+			return 0, 0
+		}
+		c = b[n]
+		n++
+		value = (value+1)<<7 | uint64(c&0x7f)
+	}
+	return value, n
+}
+
+// IsAncestor reports whether ancestor is reachable from descendant by
+// following parents. It gives up after limit commits and then returns
+// ok=false, meaning the answer is unknown.
+func (r *Repo) IsAncestor(ancestor, descendant string, limit int) (reachable, ok bool, err error) {
+	seen := map[string]bool{descendant: true}
+	queue := []string{descendant}
+	for len(queue) > 0 {
+		sha := queue[0]
+		queue = queue[1:]
+		if sha == ancestor {
+			return true, true, nil
+		}
+		if len(seen) > limit {
+			return false, false, nil
+		}
+		commit, err := r.readObject(sha)
+		if err != nil {
+			// Shallow clones end at commits whose parents are missing
+			if errors.Is(err, errObjectNotFound) {
+				return false, false, nil
+			}
+			return false, false, err
+		}
+		for _, parent := range headerFields(commit.data, "parent") {
+			if !seen[parent] {
+				seen[parent] = true
+				queue = append(queue, parent)
+			}
+		}
+	}
+	return false, true, nil
+}
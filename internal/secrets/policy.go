@@ -0,0 +1,153 @@
+package secrets
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// DefaultIncludePatterns match key names that usually hold secrets
+var DefaultIncludePatterns = []string{
+	`(?i)_KEY$`,
+	`(?i)secret`,
+	// A bare PWD is the shell's working directory; DB_PWD is a password
+	`(?i)passw(or)?d|(^|_)pwd_|_pwd(_|$)`,
+	`(?i)token`,
+	`(?i)api_?key`,
+	`(?i)credential`,
+	`(?i)private_key|access_key`,
+	// Short words only count as whole key segments, e.g. TLS_CERT but not CONCERT_HALL
+	`(?i)(^|_)cert(ificate)?(_|$)`,
+	`(?i)bearer|jwt`,
+	`(?i)(^|_)dsn(_|$)|connection_string|database_url`,
+}
+
+// DefaultExcludePatterns match key names that look sensitive but only hold locations
+var DefaultExcludePatterns = []string{
+	`(?i)_(PATH|FILE|DIR)$`,
+}
+
+// DefaultEntropyThreshold is the Shannon entropy (bits per character) above which
+// a value is treated as a generated secret
+const DefaultEntropyThreshold = 4.0
+
+// minEntropyLength is the shortest value considered for entropy detection
+const minEntropyLength = 16
+
+// Policy decides which configuration values are sensitive
+type Policy struct {
+	Include          []*regexp.Regexp
+	Exclude          []*regexp.Regexp
+	EntropyThreshold float64 // 0 disables entropy detection
+}
+
+// NewPolicy compiles a policy from include/exclude regexes. User patterns are
+// added to the defaults; an exclude match always wins over include and entropy.
+func NewPolicy(include, exclude []string, entropyThreshold float64) (*Policy, error) {
+	p := &Policy{EntropyThreshold: entropyThreshold}
+
+	for _, pattern := range append(append([]string{}, DefaultIncludePatterns...), include...) {
+		re, err := compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+		if re != nil {
+			p.Include = append(p.Include, re)
+		}
+	}
+	for _, pattern := range append(append([]string{}, DefaultExcludePatterns...), exclude...) {
+		re, err := compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+		if re != nil {
+			p.Exclude = append(p.Exclude, re)
+		}
+	}
+
+	return p, nil
+}
+
+// ValidatePatterns reports the first pattern that does not compile
+func ValidatePatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		if _, err := compile(pattern); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compile compiles a single pattern, skipping blanks
+func compile(pattern string) (*regexp.Regexp, error) {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		return nil, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid secret pattern %q: %w", pattern, err)
+	}
+	return re, nil
+}
+
+// IsSensitive reports whether a key/value pair should be masked
+func (p *Policy) IsSensitive(key, value string) bool {
+	key = strings.TrimSpace(key)
+	for _, re := range p.Exclude {
+		if re.MatchString(key) {
+			return false
+		}
+	}
+	for _, re := range p.Include {
+		if re.MatchString(key) {
+			return true
+		}
+	}
+	return p.EntropyThreshold > 0 && looksRandom(value, p.EntropyThreshold)
+}
+
+// looksRandom reports whether a value is long, has no spaces and high entropy
+func looksRandom(value string, threshold float64) bool {
+	if len(value) < minEntropyLength {
+		return false
+	}
+	for _, r := range value {
+		if unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return ShannonEntropy(value) >= threshold
+}
+
+// ShannonEntropy returns the entropy of s in bits per character
+func ShannonEntropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+	counts := make(map[rune]int)
+	total := 0
+	for _, r := range s {
+		counts[r]++
+		total++
+	}
+	entropy := 0.0
+	for _, c := range counts {
+		p := float64(c) / float64(total)
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// Mask returns a masked version of the value, showing first 2 and last 2 chars
+func Mask(value string) string {
+	if len(value) == 0 {
+		return "(empty)"
+	}
+	if len(value) <= 4 {
+		return "****"
+	}
+	return value[:2] + strings.Repeat("*", len(value)-4) + value[len(value)-2:]
+}
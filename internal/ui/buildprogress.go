@@ -0,0 +1,216 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Kavirubc/wso2-amp-cli/internal/api"
+	"github.com/charmbracelet/bubbles/progress"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// buildLogTail is how many of the newest log lines the progress view shows
+const buildLogTail = 10
+
+var buildStepPendingStyle = lipgloss.NewStyle().
+	Foreground(Gray400)
+
+// BuildProgress is one poll of a running build
+type BuildProgress struct {
+	Build *api.BuildDetailsResponse
+	// Lines are log lines not seen in earlier polls
+	Lines []LogLine
+	// Done ends the view, e.g. when the build finished or the wait timed out
+	Done bool
+}
+
+// BuildPollFunc fetches the current state of the build
+type BuildPollFunc func() (BuildProgress, error)
+
+type buildTickMsg struct{}
+
+type buildPollMsg struct {
+	progress BuildProgress
+	err      error
+}
+
+// BuildProgressModel shows build steps, overall progress and the newest log lines
+// until the build finishes
+type BuildProgressModel struct {
+	title    string
+	poll     BuildPollFunc
+	interval time.Duration
+
+	build       *api.BuildDetailsResponse
+	lines       []LogLine
+	err         error
+	done        bool
+	interrupted bool
+	width       int
+
+	bar     progress.Model
+	spinner spinner.Model
+}
+
+// NewBuildProgressModel creates a progress view that polls at the given interval
+func NewBuildProgressModel(title string, poll BuildPollFunc, interval time.Duration) BuildProgressModel {
+	bar := progress.New(progress.WithSolidFill(string(Orange500)))
+	bar.Width = 40
+	sp := spinner.New()
+	sp.Spinner = spinner.MiniDot
+	sp.Style = lipgloss.NewStyle().Foreground(Orange500)
+	return BuildProgressModel{title: title, poll: poll, interval: interval, bar: bar, spinner: sp}
+}
+
+// Build returns the last state of the build, nil if it was never fetched
+func (m BuildProgressModel) Build() *api.BuildDetailsResponse {
+	return m.build
+}
+
+// Interrupted reports whether the user stopped the view before the build finished
+func (m BuildProgressModel) Interrupted() bool {
+	return m.interrupted
+}
+
+func (m BuildProgressModel) Init() tea.Cmd {
+	return tea.Batch(m.fetch(), m.spinner.Tick)
+}
+
+func (m BuildProgressModel) tick() tea.Cmd {
+	return tea.Tick(m.interval, func(time.Time) tea.Msg { return buildTickMsg{} })
+}
+
+func (m BuildProgressModel) fetch() tea.Cmd {
+	poll := m.poll
+	return func() tea.Msg {
+		p, err := poll()
+		return buildPollMsg{progress: p, err: err}
+	}
+}
+
+func (m BuildProgressModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q", "esc":
+			m.interrupted = true
+			return m, tea.Quit
+		}
+		return m, nil
+
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
+	case buildTickMsg:
+		return m, m.fetch()
+
+	case buildPollMsg:
+		// Poll errors are shown but waiting goes on; the caller decides when to stop
+		m.err = msg.err
+		if msg.progress.Build != nil {
+			m.build = msg.progress.Build
+		}
+		m.lines = append(m.lines, msg.progress.Lines...)
+		if len(m.lines) > buildLogTail {
+			m.lines = m.lines[len(m.lines)-buildLogTail:]
+		}
+		if msg.progress.Done {
+			m.done = true
+			return m, tea.Quit
+		}
+		return m, m.tick()
+	}
+	return m, nil
+}
+
+func (m BuildProgressModel) View() string {
+	var b strings.Builder
+	b.WriteString(viewerHeaderStyle.Render(m.title))
+	b.WriteString("\n\n")
+
+	if m.build == nil {
+		b.WriteString("  " + m.spinner.View() + " waiting for build status…\n")
+	} else {
+		b.WriteString(fmt.Sprintf("  %s  %s %s  %s\n\n",
+			m.bar.ViewAs(m.build.Percent/100),
+			m.stepIcon(m.build.Status), m.build.Status,
+			MutedStyle.Render(buildElapsed(m.build))))
+		for _, step := range m.build.Steps {
+			b.WriteString("  " + m.stepIcon(step.Status) + " " + step.Type)
+			if step.Message != "" && step.Message != step.Type {
+				b.WriteString(MutedStyle.Render("  " + step.Message))
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	if len(m.lines) > 0 {
+		b.WriteString("\n")
+		for _, line := range m.lines {
+			b.WriteString("  " + m.renderLine(line) + "\n")
+		}
+	}
+
+	b.WriteString("\n")
+	if m.err != nil {
+		b.WriteString(ErrorStyle.Render(IconError+" "+m.err.Error()) + "\n")
+	}
+	if !m.done {
+		b.WriteString(helpHintStyle.Render("q stop waiting (the build keeps running)") + "\n")
+	}
+	return b.String()
+}
+
+// stepIcon marks a build step by its status
+func (m BuildProgressModel) stepIcon(status string) string {
+	switch s := strings.ToLower(status); {
+	case strings.Contains(s, "fail"), strings.Contains(s, "error"):
+		return ErrorStyle.Render(IconError)
+	case strings.Contains(s, "succeed"), strings.Contains(s, "complete"), s == "success":
+		return SuccessStyle.Render(IconSuccess)
+	case strings.Contains(s, "run"), strings.Contains(s, "progress"):
+		return m.spinner.View()
+	default:
+		return buildStepPendingStyle.Render("○")
+	}
+}
+
+// renderLine renders a log line, cut to the terminal width
+func (m BuildProgressModel) renderLine(line LogLine) string {
+	timestamp := "--:--:--"
+	if !line.Time.IsZero() {
+		timestamp = line.Time.Local().Format("15:04:05")
+	}
+	prefix := LogTimestampStyle.Render(timestamp) + " "
+	used := 2 + len(timestamp) + 1
+	if level := FormatLogLevel(line.Level); level != "" {
+		prefix += level + " "
+		used += lipgloss.Width(level) + 1
+	}
+	message := line.Message
+	if room := m.width - used; m.width > 0 && room > 0 && len([]rune(message)) > room {
+		message = string([]rune(message)[:room-1]) + "…"
+	}
+	return prefix + message
+}
+
+// buildElapsed formats how long the build has been running
+func buildElapsed(build *api.BuildDetailsResponse) string {
+	if build.StartedAt.IsZero() {
+		return ""
+	}
+	end := time.Now()
+	if build.EndedAt != nil {
+		end = *build.EndedAt
+	}
+	return end.Sub(build.StartedAt).Round(time.Second).String()
+}
@@ -0,0 +1,201 @@
+package ui
+
+import (
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Block characters used for charts, from lowest to highest
+var (
+	sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+	areaBlocks  = []rune(" ▁▂▃▄▅▆▇█")
+)
+
+// Chart colours
+var (
+	ChartUsageColor   = Teal500
+	ChartRequestColor = Blue400
+	ChartLimitColor   = Red600
+
+	chartAxisStyle = lipgloss.NewStyle().
+			Foreground(Gray500)
+)
+
+// ChartReference is a horizontal reference line such as a request or limit
+type ChartReference struct {
+	Name  string
+	Value float64
+	Color lipgloss.Color
+}
+
+// Chart describes an area chart of a single series with optional reference lines
+type Chart struct {
+	Values     []float64
+	References []ChartReference
+	Width      int // total width including the axis labels
+	Height     int // plot rows
+	Format     func(float64) string
+	StartLabel string
+	EndLabel   string
+}
+
+// Sparkline renders values as a single line of block characters, resampled to width
+func Sparkline(values []float64, width int) string {
+	values = resample(values, width)
+	if len(values) == 0 {
+		return ""
+	}
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+	var b strings.Builder
+	for _, v := range values {
+		i := len(sparkBlocks) - 1
+		if hi > lo {
+			i = int((v - lo) / (hi - lo) * float64(len(sparkBlocks)-1))
+		}
+		b.WriteRune(sparkBlocks[i])
+	}
+	return b.String()
+}
+
+// RenderChart renders the chart with a y-axis, reference lines and time labels
+func RenderChart(c Chart) string {
+	if c.Height < 2 {
+		c.Height = 2
+	}
+	format := c.Format
+	if format == nil {
+		format = func(v float64) string { return FormatCPUValue(v) }
+	}
+
+	// Scale from zero to the highest value or reference, with some headroom
+	yMax := 0.0
+	for _, v := range c.Values {
+		yMax = math.Max(yMax, v)
+	}
+	for _, r := range c.References {
+		yMax = math.Max(yMax, r.Value)
+	}
+	if yMax <= 0 {
+		yMax = 1
+	}
+	yMax *= 1.05
+
+	// Axis labels on the top, middle and bottom rows
+	labels := make([]string, c.Height)
+	labels[0] = format(yMax)
+	labels[c.Height/2] = format(yMax * float64(c.Height-1-c.Height/2) / float64(c.Height-1))
+	labels[c.Height-1] = format(0)
+	labelWidth := 0
+	for _, l := range labels {
+		labelWidth = max(labelWidth, lipgloss.Width(l))
+	}
+
+	plotWidth := c.Width - labelWidth - 2
+	if plotWidth < 10 {
+		plotWidth = 10
+	}
+	values := resample(c.Values, plotWidth)
+
+	// Row of each reference line, counted from the bottom
+	refRows := make(map[int]ChartReference)
+	for _, r := range c.References {
+		row := int(math.Round(r.Value / yMax * float64(c.Height-1)))
+		refRows[row] = r
+	}
+
+	usageStyle := lipgloss.NewStyle().Foreground(ChartUsageColor)
+	var b strings.Builder
+	for line := 0; line < c.Height; line++ {
+		row := c.Height - 1 - line
+		b.WriteString(chartAxisStyle.Render(padLeft(labels[line], labelWidth) + " ┤"))
+
+		ref, hasRef := refRows[row]
+		refStyle := lipgloss.NewStyle().Foreground(ref.Color)
+		var cells strings.Builder
+		for _, v := range values {
+			// Height of the area in eighths of a row
+			eighths := int(math.Round(v / yMax * float64(c.Height) * 8))
+			fill := eighths - row*8
+			switch {
+			case fill >= 8:
+				cells.WriteString(usageStyle.Render(string(areaBlocks[8])))
+			case fill > 0:
+				cells.WriteString(usageStyle.Render(string(areaBlocks[fill])))
+			case hasRef:
+				cells.WriteString(refStyle.Render("╌"))
+			default:
+				cells.WriteString(" ")
+			}
+		}
+		b.WriteString(cells.String())
+		if hasRef {
+			b.WriteString(" " + refStyle.Render(ref.Name+" "+format(ref.Value)))
+		}
+		b.WriteString("\n")
+	}
+
+	// Time axis
+	b.WriteString(chartAxisStyle.Render(strings.Repeat(" ", labelWidth+1) + "└" + strings.Repeat("─", plotWidth)))
+	b.WriteString("\n")
+	if c.StartLabel != "" || c.EndLabel != "" {
+		gap := plotWidth - lipgloss.Width(c.StartLabel) - lipgloss.Width(c.EndLabel)
+		if gap < 1 {
+			gap = 1
+		}
+		b.WriteString(MutedStyle.Render(strings.Repeat(" ", labelWidth+2) + c.StartLabel + strings.Repeat(" ", gap) + c.EndLabel))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// ChartLegend renders a one-line legend for the usage series and references
+func ChartLegend(usage string, refs []ChartReference, format func(float64) string) string {
+	parts := []string{lipgloss.NewStyle().Foreground(ChartUsageColor).Render("█ " + usage)}
+	for _, r := range refs {
+		parts = append(parts, lipgloss.NewStyle().Foreground(r.Color).Render("╌ "+r.Name+" "+format(r.Value)))
+	}
+	return strings.Join(parts, "   ")
+}
+
+// resample fits values into exactly width columns, averaging when there are more
+// values than columns and repeating them when there are fewer
+func resample(values []float64, width int) []float64 {
+	if width <= 0 || len(values) == 0 || len(values) == width {
+		return values
+	}
+	out := make([]float64, width)
+	if len(values) < width {
+		for i := range out {
+			out[i] = values[i*len(values)/width]
+		}
+		return out
+	}
+	for i := range out {
+		start := i * len(values) / width
+		end := (i + 1) * len(values) / width
+		if end <= start {
+			end = start + 1
+		}
+		sum := 0.0
+		for _, v := range values[start:end] {
+			sum += v
+		}
+		out[i] = sum / float64(end-start)
+	}
+	return out
+}
+
+// padLeft right-aligns s within width
+func padLeft(s string, width int) string {
+	if w := lipgloss.Width(s); w < width {
+		return strings.Repeat(" ", width-w) + s
+	}
+	return s
+}
@@ -0,0 +1,348 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	chatUserStyle = lipgloss.NewStyle().
+			Foreground(Orange500).
+			Bold(true)
+
+	chatAgentStyle = lipgloss.NewStyle().
+			Foreground(Teal500).
+			Bold(true)
+
+	chatMetaStyle = lipgloss.NewStyle().
+			Foreground(Gray500)
+)
+
+// Chat roles
+const (
+	ChatRoleUser      = "user"
+	ChatRoleAssistant = "assistant"
+)
+
+// ChatMessage is one message of a conversation
+type ChatMessage struct {
+	Role      string    `json:"role"`
+	Content   string    `json:"content"`
+	Time      time.Time `json:"time"`
+	TraceID   string    `json:"traceId,omitempty"`
+	LatencyMs int64     `json:"latencyMs,omitempty"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// ChatOptions connects the chat UI to an agent and to transcript storage
+type ChatOptions struct {
+	Title string
+	// Send delivers the conversation so far and calls onChunk with each streamed
+	// piece of the reply. It returns the completed assistant message.
+	Send func(ctx context.Context, history []ChatMessage, onChunk func(string)) (ChatMessage, error)
+	// Reset starts a new conversation, e.g. with a new session id
+	Reset func()
+	Save  func(path string, messages []ChatMessage) error
+	Load  func(path string) ([]ChatMessage, error)
+	// TraceHint returns the command that shows a trace
+	TraceHint func(traceID string) string
+}
+
+// chatChunkMsg carries a streamed piece of the reply
+type chatChunkMsg string
+
+// chatDoneMsg ends a turn
+type chatDoneMsg struct {
+	message ChatMessage
+	err     error
+}
+
+// ChatModel is the Bubble Tea model for the chat REPL
+type ChatModel struct {
+	opts     ChatOptions
+	messages []ChatMessage
+	input    textinput.Model
+	viewport viewport.Model
+	ready    bool
+	width    int
+
+	streaming bool
+	partial   string
+	stream    chan tea.Msg
+	cancel    context.CancelFunc
+	status    string
+}
+
+// NewChatModel creates a chat UI, optionally continuing an earlier conversation
+func NewChatModel(opts ChatOptions, history []ChatMessage) ChatModel {
+	ti := textinput.New()
+	ti.Placeholder = "message, or /help"
+	ti.Prompt = "› "
+	ti.PromptStyle = promptStyle
+	ti.TextStyle = inputStyle
+	ti.CharLimit = 0
+	ti.Focus()
+
+	return ChatModel{opts: opts, messages: history, input: ti}
+}
+
+// Messages returns the conversation, e.g. to save it on exit
+func (m ChatModel) Messages() []ChatMessage {
+	return m.messages
+}
+
+func (m ChatModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m ChatModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		height := msg.Height - 4 // header + blank + input + status
+		if height < 1 {
+			height = 1
+		}
+		if !m.ready {
+			m.viewport = viewport.New(msg.Width, height)
+			m.ready = true
+		} else {
+			m.viewport.Width = msg.Width
+			m.viewport.Height = height
+		}
+		m.input.Width = msg.Width - 4
+		m.refresh()
+		return m, nil
+
+	case chatChunkMsg:
+		m.partial += string(msg)
+		m.refresh()
+		return m, waitForChat(m.stream)
+
+	case chatDoneMsg:
+		m.streaming = false
+		m.cancel = nil
+		reply := msg.message
+		reply.Role = ChatRoleAssistant
+		if reply.Time.IsZero() {
+			reply.Time = time.Now()
+		}
+		if reply.Content == "" {
+			reply.Content = m.partial
+		}
+		if msg.err != nil {
+			reply.Error = msg.err.Error()
+		}
+		m.partial = ""
+		m.messages = append(m.messages, reply)
+		m.refresh()
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyCtrlC:
+			if m.cancel != nil {
+				m.cancel()
+			}
+			return m, tea.Quit
+		case tea.KeyEsc:
+			// Stop the reply in progress but keep the conversation
+			if m.cancel != nil {
+				m.cancel()
+				m.status = "cancelled"
+			}
+			return m, nil
+		case tea.KeyPgUp, tea.KeyPgDown, tea.KeyUp, tea.KeyDown:
+			var cmd tea.Cmd
+			m.viewport, cmd = m.viewport.Update(msg)
+			return m, cmd
+		case tea.KeyEnter:
+			if m.streaming {
+				return m, nil
+			}
+			text := strings.TrimSpace(m.input.Value())
+			m.input.SetValue("")
+			if text == "" {
+				return m, nil
+			}
+			if strings.HasPrefix(text, "/") {
+				return m.command(text)
+			}
+			return m.send(text)
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// send appends the user message and starts streaming the reply
+func (m ChatModel) send(text string) (tea.Model, tea.Cmd) {
+	m.status = ""
+	m.messages = append(m.messages, ChatMessage{Role: ChatRoleUser, Content: text, Time: time.Now()})
+	m.streaming = true
+	m.partial = ""
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+	stream := make(chan tea.Msg)
+	m.stream = stream
+	history := append([]ChatMessage(nil), m.messages...)
+	send := m.opts.Send
+	go func() {
+		defer close(stream)
+		reply, err := send(ctx, history, func(chunk string) {
+			select {
+			case stream <- chatChunkMsg(chunk):
+			case <-ctx.Done():
+			}
+		})
+		stream <- chatDoneMsg{message: reply, err: err}
+	}()
+
+	m.refresh()
+	return m, waitForChat(stream)
+}
+
+// waitForChat reads the next streamed message
+func waitForChat(stream chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-stream
+		if !ok {
+			return nil
+		}
+		return msg
+	}
+}
+
+// command runs a slash command
+func (m ChatModel) command(text string) (tea.Model, tea.Cmd) {
+	name, arg, _ := strings.Cut(text, " ")
+	arg = strings.TrimSpace(arg)
+	switch name {
+	case "/quit", "/exit", "/q":
+		return m, tea.Quit
+	case "/reset", "/clear":
+		m.messages = nil
+		if m.opts.Reset != nil {
+			m.opts.Reset()
+		}
+		m.status = "started a new conversation"
+	case "/save":
+		if arg == "" {
+			m.status = "usage: /save <file>"
+		} else if err := m.opts.Save(arg, m.messages); err != nil {
+			m.status = "save failed: " + err.Error()
+		} else {
+			m.status = fmt.Sprintf("saved %d messages to %s", len(m.messages), arg)
+		}
+	case "/load":
+		if arg == "" {
+			m.status = "usage: /load <file>"
+		} else if messages, err := m.opts.Load(arg); err != nil {
+			m.status = "load failed: " + err.Error()
+		} else {
+			m.messages = messages
+			m.status = fmt.Sprintf("loaded %d messages from %s", len(messages), arg)
+		}
+	case "/help":
+		m.status = "/reset new conversation • /save <file> • /load <file> • /quit • esc stop reply • pgup/pgdown scroll"
+	default:
+		m.status = fmt.Sprintf("unknown command %s, try /help", name)
+	}
+	m.refresh()
+	return m, nil
+}
+
+// refresh re-renders the conversation and scrolls to the end
+func (m *ChatModel) refresh() {
+	if !m.ready {
+		return
+	}
+	width := m.width - 2
+	if width < 20 {
+		width = 20
+	}
+	body := lipgloss.NewStyle().Width(width).PaddingLeft(2)
+
+	var b strings.Builder
+	for _, msg := range m.messages {
+		b.WriteString(m.renderMessage(msg, body))
+		b.WriteString("\n")
+	}
+	if m.streaming {
+		b.WriteString(chatAgentStyle.Render("Agent") + "\n")
+		if m.partial == "" {
+			b.WriteString(body.Render(chatMetaStyle.Render("…")))
+		} else {
+			b.WriteString(body.Render(m.partial + chatMetaStyle.Render("▌")))
+		}
+		b.WriteString("\n")
+	}
+	m.viewport.SetContent(b.String())
+	m.viewport.GotoBottom()
+}
+
+// renderMessage renders one message with its trace and latency
+func (m ChatModel) renderMessage(msg ChatMessage, body lipgloss.Style) string {
+	var b strings.Builder
+	if msg.Role == ChatRoleUser {
+		b.WriteString(chatUserStyle.Render("You") + "\n")
+	} else {
+		b.WriteString(chatAgentStyle.Render("Agent") + "\n")
+	}
+	if msg.Content != "" {
+		b.WriteString(body.Render(msg.Content) + "\n")
+	}
+	if msg.Error != "" {
+		b.WriteString(body.Render(ErrorStyle.Render(IconError+" "+msg.Error)) + "\n")
+	}
+	if msg.Role == ChatRoleAssistant {
+		var meta []string
+		if msg.LatencyMs > 0 {
+			meta = append(meta, fmt.Sprintf("%dms", msg.LatencyMs))
+		}
+		if msg.TraceID != "" {
+			hint := "trace " + msg.TraceID
+			if m.opts.TraceHint != nil {
+				hint = m.opts.TraceHint(msg.TraceID)
+			}
+			meta = append(meta, hint)
+		}
+		if len(meta) > 0 {
+			b.WriteString(body.Render(chatMetaStyle.Render(strings.Join(meta, " • "))) + "\n")
+		}
+	}
+	return b.String()
+}
+
+func (m ChatModel) View() string {
+	if !m.ready {
+		return "Loading..."
+	}
+	var b strings.Builder
+	b.WriteString(viewerHeaderStyle.Render(m.opts.Title))
+	b.WriteString("\n")
+	b.WriteString(m.viewport.View())
+	b.WriteString("\n")
+	b.WriteString(m.input.View())
+	b.WriteString("\n")
+
+	status := m.status
+	if m.streaming {
+		status = "receiving reply… esc to stop"
+	}
+	if status == "" {
+		status = fmt.Sprintf("%d messages • /help for commands • ctrl+c to exit", len(m.messages))
+	}
+	b.WriteString(helpHintStyle.Render(status))
+	return b.String()
+}
@@ -27,6 +27,28 @@ var (
 				Foreground(Gray600)
 )
 
+// AgentColors are cycled through to tell agents apart in aggregated logs
+var AgentColors = []lipgloss.Color{
+	Teal500,
+	Indigo700,
+	Green500,
+	Orange600,
+	Blue400,
+	Yellow500,
+	Teal700,
+	Indigo500,
+}
+
+// AgentLogStyle returns the prefix style for the agent at the given index
+func AgentLogStyle(index int) lipgloss.Style {
+	if index < 0 {
+		index = -index
+	}
+	return lipgloss.NewStyle().
+		Foreground(AgentColors[index%len(AgentColors)]).
+		Bold(true)
+}
+
 // LogLevelStyle returns the appropriate style for a log level
 func LogLevelStyle(level string) lipgloss.Style {
 	switch strings.ToUpper(level) {
@@ -0,0 +1,536 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/atotto/clipboard"
+	"github.com/aymanbagabas/go-osc52/v2"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	viewerHeaderStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(Orange500)
+
+	viewerStatusStyle = lipgloss.NewStyle().
+				Foreground(Gray600)
+
+	viewerCursorStyle = lipgloss.NewStyle().
+				Foreground(Orange500).
+				Bold(true)
+
+	viewerMatchStyle = lipgloss.NewStyle().
+				Foreground(Black).
+				Background(Yellow500)
+
+	viewerLevelOnStyle = lipgloss.NewStyle().
+				Bold(true)
+
+	viewerLevelOffStyle = lipgloss.NewStyle().
+				Foreground(Gray400).
+				Strikethrough(true)
+)
+
+// LogViewerLevels are the levels that can be toggled with keys 1-4
+var LogViewerLevels = []string{"ERROR", "WARN", "INFO", "DEBUG"}
+
+// LogLine is a single line shown in the log viewer
+type LogLine struct {
+	Time    time.Time
+	Source  string
+	Level   string
+	Message string
+}
+
+// LogPollFunc fetches lines that arrived since the previous call
+type LogPollFunc func() ([]LogLine, error)
+
+// viewer input modes
+type viewerMode int
+
+const (
+	viewerNormal viewerMode = iota
+	viewerSearch
+	viewerJump
+)
+
+// logTickMsg triggers the next poll
+type logTickMsg struct{}
+
+// logPollMsg carries the result of a poll
+type logPollMsg struct {
+	lines []LogLine
+	err   error
+}
+
+// LogViewerModel is the Bubble Tea model for the full-screen log viewer
+type LogViewerModel struct {
+	title    string
+	lines    []LogLine
+	visible  []int // indices into lines that pass the level filter
+	selected int   // index into visible
+	hidden   map[string]bool
+	sources  map[string]int
+
+	search string
+	mode   viewerMode
+	input  textinput.Model
+
+	poll     LogPollFunc
+	interval time.Duration
+	paused   bool
+	status   string
+
+	viewport viewport.Model
+	width    int
+	ready    bool
+}
+
+// NewLogViewerModel creates a viewer over the given lines. When poll is non-nil the
+// viewer follows new lines every interval until paused.
+func NewLogViewerModel(title string, lines []LogLine, poll LogPollFunc, interval time.Duration) LogViewerModel {
+	ti := textinput.New()
+	ti.CharLimit = 256
+	ti.PromptStyle = promptStyle
+	ti.TextStyle = inputStyle
+
+	m := LogViewerModel{
+		title:    title,
+		hidden:   make(map[string]bool),
+		sources:  make(map[string]int),
+		input:    ti,
+		poll:     poll,
+		interval: interval,
+	}
+	m.appendLines(lines)
+	m.selected = len(m.visible) - 1
+	return m
+}
+
+func (m LogViewerModel) Init() tea.Cmd {
+	if m.poll == nil {
+		return nil
+	}
+	return m.tick()
+}
+
+func (m LogViewerModel) tick() tea.Cmd {
+	return tea.Tick(m.interval, func(time.Time) tea.Msg { return logTickMsg{} })
+}
+
+func (m LogViewerModel) fetch() tea.Cmd {
+	poll := m.poll
+	return func() tea.Msg {
+		lines, err := poll()
+		return logPollMsg{lines: lines, err: err}
+	}
+}
+
+func (m LogViewerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		height := msg.Height - 3 // header + status + footer
+		if height < 1 {
+			height = 1
+		}
+		if !m.ready {
+			m.viewport = viewport.New(msg.Width, height)
+			m.ready = true
+		} else {
+			m.viewport.Width = msg.Width
+			m.viewport.Height = height
+		}
+		m.refresh()
+		return m, nil
+
+	case logTickMsg:
+		if m.paused {
+			return m, m.tick()
+		}
+		return m, m.fetch()
+
+	case logPollMsg:
+		if msg.err != nil {
+			m.status = fmt.Sprintf("poll failed: %v", msg.err)
+		} else if len(msg.lines) > 0 {
+			// Keep following the tail only when the cursor is already there
+			atEnd := m.selected >= len(m.visible)-1
+			m.appendLines(msg.lines)
+			if atEnd {
+				m.selected = len(m.visible) - 1
+			}
+			m.refresh()
+		}
+		return m, m.tick()
+
+	case tea.KeyMsg:
+		if m.mode != viewerNormal {
+			return m.updateInput(msg)
+		}
+		return m.updateNormal(msg)
+	}
+
+	return m, nil
+}
+
+// updateNormal handles keys while browsing
+func (m LogViewerModel) updateNormal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.status = ""
+	switch msg.String() {
+	case "q", "ctrl+c", "esc":
+		return m, tea.Quit
+	case "up", "k":
+		m.move(-1)
+	case "down", "j":
+		m.move(1)
+	case "pgup", "ctrl+u":
+		m.move(-m.viewport.Height)
+	case "pgdown", "ctrl+d":
+		m.move(m.viewport.Height)
+	case "home", "g":
+		m.selected = 0
+	case "end", "G":
+		m.selected = len(m.visible) - 1
+	case "/":
+		m.mode = viewerSearch
+		m.input.Prompt = "/"
+		m.input.Placeholder = "search"
+		m.input.SetValue(m.search)
+		m.input.Focus()
+		return m, textinput.Blink
+	case "t":
+		m.mode = viewerJump
+		m.input.Prompt = "jump to time: "
+		m.input.Placeholder = "15:04:05 or 2025-01-20T15:04:05Z"
+		m.input.SetValue("")
+		m.input.Focus()
+		return m, textinput.Blink
+	case "n":
+		m.nextMatch(m.selected+1, 1)
+	case "N":
+		m.nextMatch(m.selected-1, -1)
+	case "1", "2", "3", "4":
+		level := LogViewerLevels[msg.String()[0]-'1']
+		m.hidden[level] = !m.hidden[level]
+		m.rebuildVisible()
+	case " ", "p":
+		if m.poll != nil {
+			m.paused = !m.paused
+		}
+	case "y", "c":
+		m.copySelected()
+	}
+	m.refresh()
+	return m, nil
+}
+
+// updateInput handles keys while typing a search or a time
+func (m LogViewerModel) updateInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyEsc:
+		if m.mode == viewerSearch {
+			m.search = ""
+		}
+		m.mode = viewerNormal
+		m.input.Blur()
+		m.refresh()
+		return m, nil
+	case tea.KeyEnter:
+		if m.mode == viewerJump {
+			if err := m.jumpTo(m.input.Value()); err != nil {
+				m.status = err.Error()
+			}
+		}
+		m.mode = viewerNormal
+		m.input.Blur()
+		m.refresh()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	if m.mode == viewerSearch {
+		// Incremental search: highlight as you type and jump to the first match
+		m.search = m.input.Value()
+		m.nextMatch(m.selected, 1)
+		m.refresh()
+	}
+	return m, cmd
+}
+
+// appendLines adds lines and assigns colours to new sources
+func (m *LogViewerModel) appendLines(lines []LogLine) {
+	for _, line := range lines {
+		if _, ok := m.sources[line.Source]; !ok && line.Source != "" {
+			m.sources[line.Source] = len(m.sources)
+		}
+		m.lines = append(m.lines, line)
+		if !m.hidden[normalizeLevel(line.Level)] {
+			m.visible = append(m.visible, len(m.lines)-1)
+		}
+	}
+}
+
+// rebuildVisible reapplies level toggles, keeping the cursor on the same line if possible
+func (m *LogViewerModel) rebuildVisible() {
+	current := -1
+	if m.selected >= 0 && m.selected < len(m.visible) {
+		current = m.visible[m.selected]
+	}
+	m.visible = m.visible[:0]
+	m.selected = 0
+	for i, line := range m.lines {
+		if m.hidden[normalizeLevel(line.Level)] {
+			continue
+		}
+		if i <= current {
+			m.selected = len(m.visible)
+		}
+		m.visible = append(m.visible, i)
+	}
+}
+
+func (m *LogViewerModel) move(delta int) {
+	m.selected += delta
+}
+
+// nextMatch moves the cursor to the next line containing the search term
+func (m *LogViewerModel) nextMatch(from, step int) {
+	if m.search == "" || len(m.visible) == 0 {
+		return
+	}
+	term := strings.ToLower(m.search)
+	for n := 0; n < len(m.visible); n++ {
+		i := ((from+step*n)%len(m.visible) + len(m.visible)) % len(m.visible)
+		if strings.Contains(strings.ToLower(m.lines[m.visible[i]].Message), term) {
+			m.selected = i
+			return
+		}
+	}
+	m.status = fmt.Sprintf("no match for %q", m.search)
+}
+
+// jumpTo moves the cursor to the first line at or after the given time
+func (m *LogViewerModel) jumpTo(value string) error {
+	value = strings.TrimSpace(value)
+	target, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		clock, clockErr := parseClock(value)
+		if clockErr != nil {
+			return fmt.Errorf("invalid time %q", value)
+		}
+		// Clock times are matched against the day of the selected line
+		ref := time.Now()
+		if m.selected >= 0 && m.selected < len(m.visible) {
+			ref = m.lines[m.visible[m.selected]].Time.Local()
+		}
+		target = time.Date(ref.Year(), ref.Month(), ref.Day(), clock.Hour(), clock.Minute(), clock.Second(), 0, time.Local)
+	}
+	for i, idx := range m.visible {
+		if !m.lines[idx].Time.Before(target) {
+			m.selected = i
+			return nil
+		}
+	}
+	m.selected = len(m.visible) - 1
+	return nil
+}
+
+func parseClock(value string) (time.Time, error) {
+	for _, layout := range []string{"15:04:05", "15:04"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid clock time")
+}
+
+// copySelected copies the selected line to the system clipboard, falling back to OSC 52
+func (m *LogViewerModel) copySelected() {
+	if m.selected < 0 || m.selected >= len(m.visible) {
+		return
+	}
+	text := plainLogLine(m.lines[m.visible[m.selected]])
+	if err := clipboard.WriteAll(text); err != nil {
+		if _, err := osc52.New(text).WriteTo(os.Stderr); err != nil {
+			m.status = fmt.Sprintf("copy failed: %v", err)
+			return
+		}
+	}
+	m.status = "Copied line to clipboard"
+}
+
+// refresh clamps the cursor, re-renders the lines and scrolls the cursor into view
+func (m *LogViewerModel) refresh() {
+	if m.selected >= len(m.visible) {
+		m.selected = len(m.visible) - 1
+	}
+	if m.selected < 0 {
+		m.selected = 0
+	}
+	if !m.ready {
+		return
+	}
+
+	rendered := make([]string, len(m.visible))
+	for i, idx := range m.visible {
+		rendered[i] = m.renderLine(m.lines[idx], i == m.selected)
+	}
+	m.viewport.SetContent(strings.Join(rendered, "\n"))
+
+	if m.selected < m.viewport.YOffset {
+		m.viewport.SetYOffset(m.selected)
+	} else if m.selected >= m.viewport.YOffset+m.viewport.Height {
+		m.viewport.SetYOffset(m.selected - m.viewport.Height + 1)
+	}
+}
+
+// renderLine styles one line, truncated to the window width
+func (m LogViewerModel) renderLine(line LogLine, selected bool) string {
+	cursor := "  "
+	if selected {
+		cursor = viewerCursorStyle.Render("▌ ")
+	}
+
+	timestamp := "--:--:--"
+	if !line.Time.IsZero() {
+		timestamp = line.Time.Local().Format("15:04:05")
+	}
+	prefix := cursor + LogTimestampStyle.Render(timestamp) + " "
+	used := 2 + len(timestamp) + 1
+	if line.Source != "" {
+		prefix += AgentLogStyle(m.sources[line.Source]).Render(line.Source) + " "
+		used += len([]rune(line.Source)) + 1
+	}
+	if level := FormatLogLevel(line.Level); level != "" {
+		prefix += level + " "
+		used += lipgloss.Width(level) + 1
+	}
+
+	message := line.Message
+	if room := m.width - used; room > 0 && len([]rune(message)) > room {
+		message = string([]rune(message)[:room-1]) + "…"
+	}
+	return prefix + highlight(message, m.search, selected)
+}
+
+// highlight marks case-insensitive occurrences of term in s
+func highlight(s, term string, selected bool) string {
+	base := lipgloss.NewStyle()
+	if selected {
+		base = base.Bold(true)
+	}
+	if term == "" {
+		return base.Render(s)
+	}
+	lower := strings.ToLower(s)
+	needle := strings.ToLower(term)
+	var b strings.Builder
+	for {
+		i := strings.Index(lower, needle)
+		if i < 0 || len(lower) != len(s) {
+			break
+		}
+		b.WriteString(base.Render(s[:i]))
+		b.WriteString(viewerMatchStyle.Render(s[i : i+len(needle)]))
+		s, lower = s[i+len(needle):], lower[i+len(needle):]
+	}
+	b.WriteString(base.Render(s))
+	return b.String()
+}
+
+func (m LogViewerModel) View() string {
+	if !m.ready {
+		return "Loading logs..."
+	}
+
+	var b strings.Builder
+	b.WriteString(viewerHeaderStyle.Render(m.title))
+	b.WriteString("\n")
+	b.WriteString(m.viewport.View())
+	b.WriteString("\n")
+	b.WriteString(m.statusLine())
+	b.WriteString("\n")
+
+	switch m.mode {
+	case viewerSearch, viewerJump:
+		b.WriteString(m.input.View())
+	default:
+		help := "↑/↓ move • / search • n/N next/prev • 1-4 levels • t jump • y copy • q quit"
+		if m.poll != nil {
+			help = "space pause • " + help
+		}
+		b.WriteString(helpHintStyle.Render(help))
+	}
+	return b.String()
+}
+
+// statusLine shows position, level toggles, follow state and messages
+func (m LogViewerModel) statusLine() string {
+	position := 0
+	if len(m.visible) > 0 {
+		position = m.selected + 1
+	}
+	parts := []string{fmt.Sprintf("%d/%d", position, len(m.visible))}
+
+	levels := make([]string, len(LogViewerLevels))
+	for i, level := range LogViewerLevels {
+		label := fmt.Sprintf("%d:%s", i+1, level)
+		if m.hidden[level] {
+			levels[i] = viewerLevelOffStyle.Render(label)
+		} else {
+			levels[i] = viewerLevelOnStyle.Inherit(LogLevelStyle(level)).Render(label)
+		}
+	}
+	parts = append(parts, strings.Join(levels, " "))
+
+	if m.poll != nil {
+		if m.paused {
+			parts = append(parts, WarningStyle.Render("PAUSED"))
+		} else {
+			parts = append(parts, SuccessStyle.Render("FOLLOWING"))
+		}
+	}
+	if m.search != "" {
+		parts = append(parts, fmt.Sprintf("search: %q", m.search))
+	}
+	if m.status != "" {
+		parts = append(parts, m.status)
+	}
+	return viewerStatusStyle.Render(strings.Join(parts, "  │  "))
+}
+
+// plainLogLine renders a line without styling for copying
+func plainLogLine(line LogLine) string {
+	parts := []string{}
+	if !line.Time.IsZero() {
+		parts = append(parts, line.Time.Format(time.RFC3339Nano))
+	}
+	if line.Source != "" {
+		parts = append(parts, line.Source)
+	}
+	if line.Level != "" {
+		parts = append(parts, strings.ToUpper(line.Level))
+	}
+	parts = append(parts, line.Message)
+	return strings.Join(parts, " ")
+}
+
+// normalizeLevel maps level aliases to the toggle names
+func normalizeLevel(level string) string {
+	level = strings.ToUpper(level)
+	if level == "WARNING" {
+		return "WARN"
+	}
+	return level
+}
@@ -0,0 +1,147 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// pickerMaxVisible is how many rows the picker shows before scrolling
+const pickerMaxVisible = 12
+
+var (
+	pickerCursorStyle = lipgloss.NewStyle().
+				Foreground(Orange500).
+				Bold(true)
+
+	pickerHeaderStyle = lipgloss.NewStyle().
+				Foreground(Gray500).
+				Bold(true)
+)
+
+// PickerModel lets the user choose one row of a table with the arrow keys
+type PickerModel struct {
+	title    string
+	headers  []string
+	rows     [][]string
+	widths   []int
+	cursor   int
+	offset   int
+	selected int
+	done     bool
+}
+
+// NewPickerModel creates a picker over rows, which have one cell per header
+func NewPickerModel(title string, headers []string, rows [][]string) PickerModel {
+	widths := make([]int, len(headers))
+	for i, h := range headers {
+		widths[i] = lipgloss.Width(h)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			if i < len(widths) && lipgloss.Width(cell) > widths[i] {
+				widths[i] = lipgloss.Width(cell)
+			}
+		}
+	}
+	return PickerModel{
+		title:    title,
+		headers:  headers,
+		rows:     rows,
+		widths:   widths,
+		selected: -1,
+	}
+}
+
+// Selected returns the index of the chosen row, or -1 if the picker was cancelled
+func (m PickerModel) Selected() int {
+	return m.selected
+}
+
+func (m PickerModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m PickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	switch key.String() {
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.rows)-1 {
+			m.cursor++
+		}
+	case "home", "g":
+		m.cursor = 0
+	case "end", "G":
+		m.cursor = len(m.rows) - 1
+	case "enter":
+		if len(m.rows) > 0 {
+			m.selected = m.cursor
+		}
+		m.done = true
+		return m, tea.Quit
+	case "q", "esc", "ctrl+c":
+		m.done = true
+		return m, tea.Quit
+	}
+
+	// Keep the cursor inside the visible window
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+pickerMaxVisible {
+		m.offset = m.cursor - pickerMaxVisible + 1
+	}
+	return m, nil
+}
+
+func (m PickerModel) View() string {
+	// Clear the picker once a choice is made so the command output follows cleanly
+	if m.done {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(TitleStyle.Render(m.title) + "\n\n")
+	b.WriteString("    " + pickerHeaderStyle.Render(m.formatRow(m.headers)) + "\n")
+
+	end := m.offset + pickerMaxVisible
+	if end > len(m.rows) {
+		end = len(m.rows)
+	}
+	for i := m.offset; i < end; i++ {
+		line := m.formatRow(m.rows[i])
+		if i == m.cursor {
+			b.WriteString(pickerCursorStyle.Render("  ▸ "+line) + "\n")
+		} else {
+			b.WriteString("    " + line + "\n")
+		}
+	}
+	if len(m.rows) > pickerMaxVisible {
+		b.WriteString(MutedStyle.Render(fmt.Sprintf("    %d of %d", m.cursor+1, len(m.rows))) + "\n")
+	}
+
+	b.WriteString("\n" + helpHintStyle.Render("  ↑/↓ move • enter select • esc cancel") + "\n")
+	return b.String()
+}
+
+// formatRow pads each cell to its column width
+func (m PickerModel) formatRow(cells []string) string {
+	parts := make([]string, len(m.widths))
+	for i := range m.widths {
+		cell := ""
+		if i < len(cells) {
+			cell = cells[i]
+		}
+		parts[i] = cell + strings.Repeat(" ", m.widths[i]-lipgloss.Width(cell))
+	}
+	return strings.TrimRight(strings.Join(parts, "  "), " ")
+}
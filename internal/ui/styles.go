@@ -109,6 +109,7 @@ const (
 	IconBuild    = "🔨"
 	IconDeploy   = "🚀"
 	IconEndpoint = "🔗"
+	IconLogs     = "📜"
 )
 
 // RenderSuccess renders a success message
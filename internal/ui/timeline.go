@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Timeline bar styles by step state
+var (
+	timelineDoneStyle    = lipgloss.NewStyle().Foreground(Teal500)
+	timelineRunningStyle = lipgloss.NewStyle().Foreground(Yellow500)
+	timelineFailedStyle  = lipgloss.NewStyle().Foreground(Red500)
+	timelineSlowStyle    = lipgloss.NewStyle().Foreground(Orange500).Bold(true)
+)
+
+// Timeline step states
+const (
+	TimelineDone    = "done"
+	TimelineRunning = "running"
+	TimelineFailed  = "failed"
+	TimelinePending = "pending"
+)
+
+// TimelineRow is one bar of a timeline. Pending rows have no start.
+type TimelineRow struct {
+	Label   string
+	State   string
+	Start   time.Time
+	End     time.Time
+	Slowest bool
+}
+
+// RenderTimeline draws rows as horizontal bars placed on a shared time axis
+// that runs from origin to end. Width is the total width including labels.
+func RenderTimeline(rows []TimelineRow, origin, end time.Time, width int) string {
+	labelWidth := 0
+	for _, row := range rows {
+		if w := lipgloss.Width(row.Label); w > labelWidth {
+			labelWidth = w
+		}
+	}
+	const durationWidth = 10
+	barWidth := width - labelWidth - durationWidth - 6
+	if barWidth < 10 {
+		barWidth = 10
+	}
+	total := end.Sub(origin)
+
+	var b strings.Builder
+	for _, row := range rows {
+		b.WriteString("  " + row.Label + strings.Repeat(" ", labelWidth-lipgloss.Width(row.Label)) + "  ")
+
+		if row.Start.IsZero() {
+			b.WriteString(strings.Repeat(" ", barWidth) + "  " + MutedStyle.Render("pending") + "\n")
+			continue
+		}
+
+		offset, length := 0, barWidth
+		if total > 0 {
+			offset = int(float64(row.Start.Sub(origin)) / float64(total) * float64(barWidth))
+			length = int(float64(row.End.Sub(row.Start))/float64(total)*float64(barWidth) + 0.5)
+		}
+		offset = clampInt(offset, 0, barWidth-1)
+		length = clampInt(length, 1, barWidth-offset)
+
+		style := timelineDoneStyle
+		switch {
+		case row.State == TimelineFailed:
+			style = timelineFailedStyle
+		case row.Slowest:
+			style = timelineSlowStyle
+		case row.State == TimelineRunning:
+			style = timelineRunningStyle
+		}
+		b.WriteString(strings.Repeat(" ", offset))
+		b.WriteString(style.Render(strings.Repeat("█", length)))
+		b.WriteString(strings.Repeat(" ", barWidth-offset-length))
+
+		duration := FormatStepDuration(row.End.Sub(row.Start))
+		if row.State == TimelineRunning {
+			duration += "…"
+		}
+		b.WriteString("  " + padLeft(duration, durationWidth-2))
+		if row.Slowest {
+			b.WriteString(" " + timelineSlowStyle.Render("◀ slowest"))
+		}
+		b.WriteString("\n")
+	}
+
+	// Axis with the start and total duration
+	b.WriteString("  " + strings.Repeat(" ", labelWidth) + "  ")
+	endLabel := FormatStepDuration(total)
+	axis := "0s" + strings.Repeat(" ", maxInt(1, barWidth-2-len(endLabel))) + endLabel
+	b.WriteString(chartAxisStyle.Render(axis) + "\n")
+	return b.String()
+}
+
+// FormatStepDuration formats a step duration compactly, e.g. 850ms, 42s or 3m05s
+func FormatStepDuration(d time.Duration) string {
+	switch {
+	case d < time.Second:
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	case d < time.Minute:
+		return fmt.Sprintf("%.0fs", d.Seconds())
+	case d < time.Hour:
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	default:
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+}
+
+func clampInt(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
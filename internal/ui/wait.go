@@ -0,0 +1,126 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// WaitStatus is one poll of something being waited on
+type WaitStatus struct {
+	// Message describes the current state, e.g. "deploying (image abc123)"
+	Message string
+	// Done ends the view, whether the wait succeeded, failed or timed out
+	Done bool
+}
+
+// WaitPollFunc fetches the current state
+type WaitPollFunc func() (WaitStatus, error)
+
+type waitTickMsg struct{}
+
+type waitPollMsg struct {
+	status WaitStatus
+	err    error
+}
+
+// WaitModel shows a spinner and the latest status line until the poll
+// function reports that it is done
+type WaitModel struct {
+	title    string
+	poll     WaitPollFunc
+	interval time.Duration
+	started  time.Time
+
+	message     string
+	err         error
+	done        bool
+	interrupted bool
+	spinner     spinner.Model
+}
+
+// NewWaitModel creates a spinner view that polls at the given interval
+func NewWaitModel(title string, poll WaitPollFunc, interval time.Duration) WaitModel {
+	return WaitModel{
+		title:    title,
+		poll:     poll,
+		interval: interval,
+		started:  time.Now(),
+		message:  "waiting for status…",
+		spinner:  NewSpinner(),
+	}
+}
+
+// Interrupted reports whether the user stopped the view before it was done
+func (m WaitModel) Interrupted() bool {
+	return m.interrupted
+}
+
+func (m WaitModel) Init() tea.Cmd {
+	return tea.Batch(m.fetch(), m.spinner.Tick)
+}
+
+func (m WaitModel) tick() tea.Cmd {
+	return tea.Tick(m.interval, func(time.Time) tea.Msg { return waitTickMsg{} })
+}
+
+func (m WaitModel) fetch() tea.Cmd {
+	poll := m.poll
+	return func() tea.Msg {
+		status, err := poll()
+		return waitPollMsg{status: status, err: err}
+	}
+}
+
+func (m WaitModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q", "esc":
+			m.interrupted = true
+			return m, tea.Quit
+		}
+		return m, nil
+
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
+	case waitTickMsg:
+		return m, m.fetch()
+
+	case waitPollMsg:
+		// Poll errors are shown but waiting goes on; the caller decides when to stop
+		m.err = msg.err
+		if msg.status.Message != "" {
+			m.message = msg.status.Message
+		}
+		if msg.status.Done {
+			m.done = true
+			return m, tea.Quit
+		}
+		return m, m.tick()
+	}
+	return m, nil
+}
+
+func (m WaitModel) View() string {
+	// The caller prints the outcome, so the view clears itself when finished
+	if m.done || m.interrupted {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(viewerHeaderStyle.Render(m.title))
+	b.WriteString("\n\n")
+	b.WriteString("  " + m.spinner.View() + " " + m.message + "  " +
+		MutedStyle.Render(time.Since(m.started).Truncate(time.Second).String()) + "\n")
+	if m.err != nil {
+		b.WriteString("\n" + ErrorStyle.Render(IconError+" "+m.err.Error()) + "\n")
+	}
+	b.WriteString("\n" + helpHintStyle.Render("q stop waiting") + "\n")
+	return b.String()
+}